@@ -0,0 +1,177 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// entropy returns the expected information in bits of a guess, given the
+// distribution of its scores over the remaining words.
+func entropy(distribution map[string]int) float64 {
+	total, counts := 0, []int{}
+	for _, count := range distribution {
+		total += count
+		counts = append(counts, count)
+	}
+	// sum in a fixed order, so equal distributions get exactly equal entropies
+	sort.Ints(counts)
+	bits := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		bits -= p * math.Log2(p)
+	}
+	return bits
+}
+
+// bestEntropy returns the guess with the highest entropy over the remaining
+// words, taken from all words and the remaining words themselves.
+func (wg *WordGame) bestEntropy() (string, float64) {
+	bestGuess, bestBits := "", -1.0
+	for _, words := range []*[]string{wg.allWords, wg.remainingWords} {
+		for _, word := range *words {
+			bits := entropy(wg.scoreDistribution(word))
+			if bits > bestBits {
+				bestGuess, bestBits = word, bits
+			}
+		}
+	}
+	return bestGuess, bestBits
+}
+
+type turnAnalysis struct {
+	guess          string
+	score          string
+	bestGuess      string
+	remaining      int
+	remainingAfter int
+	bits           float64
+	expectedBits   float64
+	bestBits       float64
+	luck           int
+	skill          int
+}
+
+// luck returns the percentage of outcomes that would have left more words
+// than the given score, counting equally large outcomes half.
+func luck(distribution map[string]int, score string) int {
+	total, worse, equal := 0, 0, 0
+	for _, count := range distribution {
+		total += count
+		if count > distribution[score] {
+			worse += count
+		} else if count == distribution[score] {
+			equal += count
+		}
+	}
+	return int(math.Round(100 * (float64(worse) + float64(equal)/2) / float64(total)))
+}
+
+func skill(expectedBits, bestBits float64) int {
+	if bestBits <= 0 {
+		return 100
+	}
+	return int(math.Round(100 * math.Min(expectedBits/bestBits, 1)))
+}
+
+// turnBaseline is the best play in a position, the reference for rating a
+// turn.
+type turnBaseline struct {
+	bestGuess string
+	bestBits  float64
+}
+
+func (wg *WordGame) baseline() turnBaseline {
+	_, bestBits := wg.bestEntropy()
+	return turnBaseline{(*wg.getBestGuesses())[0], bestBits}
+}
+
+func (wg *WordGame) analyzeTurn(guess, answer string) *turnAnalysis {
+	return wg.analyzeScoredTurn(guess, scoreAgainst(guess, answer), wg.baseline())
+}
+
+// analyzeScoredTurn rates a guess with a known score against the baseline of
+// the current position, which is expensive for the first turn and can be
+// calculated once for many games.
+func (wg *WordGame) analyzeScoredTurn(guess, score string, baseline turnBaseline) *turnAnalysis {
+	distribution := wg.scoreDistribution(guess)
+	turn := &turnAnalysis{
+		guess:        guess,
+		score:        score,
+		bestGuess:    baseline.bestGuess,
+		remaining:    len(*wg.remainingWords),
+		expectedBits: entropy(distribution),
+		bestBits:     baseline.bestBits,
+		luck:         luck(distribution, score),
+	}
+	if turn.remaining == 1 {
+		turn.bestGuess = (*wg.remainingWords)[0]
+	}
+	turn.skill = skill(turn.expectedBits, turn.bestBits)
+	wg.guess(guess, score)
+	turn.remainingAfter = len(*wg.remainingWords)
+	turn.bits = math.Log2(float64(turn.remaining) / float64(turn.remainingAfter))
+	return turn
+}
+
+// analyzeGame replays the guesses against the answer and rates every turn.
+func analyzeGame(wg *WordGame, answer string, guesses *[]string) ([]turnAnalysis, error) {
+	answer = strings.ToUpper(answer)
+	if !containsWord(wg.remainingWords, answer) {
+		return nil, fmt.Errorf("Can't analyze a game with unknown answer '%v'", answer)
+	}
+	turns := []turnAnalysis{}
+	for _, guess := range *applyToWordSlice(strings.ToUpper, guesses) {
+		if len(guess) != len(answer) {
+			return nil, fmt.Errorf("Invalid length guess '%v'", guess)
+		}
+		turns = append(turns, *wg.analyzeTurn(guess, answer))
+		if guess == answer {
+			break
+		}
+	}
+	return turns, nil
+}
+
+func analyze(args []string) error {
+	flags := flag.NewFlagSet("analyze", flag.ExitOnError)
+	answer := flags.String("answer", "", "the solution of the game")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: WordleSolver analyze -answer WORD GUESS...")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if *answer == "" || flags.NArg() == 0 {
+		flags.Usage()
+		return fmt.Errorf("Need an answer and at least one guess")
+	}
+
+	guesses := flags.Args()
+	wg := createDefaultWordGame()
+	fmt.Fprintf(messages, "Analyze game ...\n")
+	turns, err := analyzeGame(wg, *answer, &guesses)
+	if err != nil {
+		return err
+	}
+
+	if machineOutput() {
+		r := newReport("analysis", "turn", "guess", "score", "remaining", "remaining_after", "solver",
+			"bits", "expected_bits", "best_bits", "luck", "skill")
+		for idx, turn := range turns {
+			r.add(idx+1, turn.guess, turn.score, turn.remaining, turn.remainingAfter, turn.bestGuess,
+				turn.bits, turn.expectedBits, turn.bestBits, turn.luck, turn.skill)
+		}
+		return r.emit()
+	}
+	w := tabwriter.NewWriter(messages, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Turn\tGuess\tScore\tRemaining\tSolver\tBits\tExpected\tBest\tLuck\tSkill")
+	for idx, turn := range turns {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v -> %v\t%v\t%.2f\t%.2f\t%.2f\t%v\t%v\n",
+			idx+1, turn.guess, turn.score, turn.remaining, turn.remainingAfter, turn.bestGuess,
+			turn.bits, turn.expectedBits, turn.bestBits, turn.luck, turn.skill)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func expectGotFloat(t testing.TB, expect, got float64) {
+	t.Helper()
+	if math.Abs(expect-got) > 1e-9 {
+		t.Errorf("Expected '%v' got '%v'", expect, got)
+	}
+}
+
+func TestEntropy(t *testing.T) {
+	t.Run("with a single outcome", func(t *testing.T) {
+		expectGotFloat(t, 0, entropy(map[string]int{"...": 4}))
+	})
+
+	t.Run("with uniform outcomes", func(t *testing.T) {
+		expectGotFloat(t, 2, entropy(map[string]int{"...": 1, "H..": 1, ".H.": 1, "..H": 1}))
+	})
+}
+
+func TestLuck(t *testing.T) {
+	distribution := map[string]int{"...": 6, "H..": 2, "HHH": 2}
+
+	t.Run("with the largest outcome", func(t *testing.T) {
+		got := luck(distribution, "...")
+		if got != 30 {
+			t.Errorf("Expected '30' got '%v'", got)
+		}
+	})
+
+	t.Run("with a small outcome", func(t *testing.T) {
+		got := luck(distribution, "HHH")
+		if got != 80 {
+			t.Errorf("Expected '80' got '%v'", got)
+		}
+	})
+}
+
+func TestAnalyzeGame(t *testing.T) {
+	t.Run("with a solved game", func(t *testing.T) {
+		guesses := []string{"axy", "abc"}
+		solutions := []string{"ABC", "ABD", "XYZ", "AXY"}
+		wordGame := createWordGameFromWordLists(&[]string{"AXY", "BXY", "ABZ"}, &solutions)
+		turns, err := analyzeGame(wordGame, "abc", &guesses)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(turns) != 2 {
+			t.Fatalf("Expected 2 turns, got %v", len(turns))
+		}
+		expectGotString(t, "H..", turns[0].score)
+		if turns[0].remaining != 4 || turns[0].remainingAfter != 2 {
+			t.Errorf("Expected 4 -> 2 remaining words, got %v -> %v", turns[0].remaining, turns[0].remainingAfter)
+		}
+		expectGotFloat(t, 1, turns[0].bits)
+		expectGotFloat(t, 1.5, turns[0].expectedBits)
+		if turns[1].remaining != 2 || turns[1].remainingAfter != 1 {
+			t.Errorf("Expected 2 -> 1 remaining words, got %v -> %v", turns[1].remaining, turns[1].remainingAfter)
+		}
+	})
+
+	t.Run("with unknown answer", func(t *testing.T) {
+		guesses := []string{"ABC"}
+		wordGame := createWordGame(&[]string{"ABC"}, 3)
+		if _, err := analyzeGame(wordGame, "XYZ", &guesses); err == nil {
+			t.Errorf("Expected an error for an unknown answer")
+		}
+	})
+}
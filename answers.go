@@ -0,0 +1,208 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+)
+
+const dateFormat = "2006-01-02"
+
+type pastAnswer struct {
+	date time.Time
+	word string
+}
+
+// readAnswerHistory reads a file of past answers with one "DATE WORD" entry
+// per line, e.g., "2022-02-01 WINCE". Empty lines and lines starting with
+// '#' are ignored.
+func readAnswerHistory(path string) (*[]pastAnswer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Can't read answer history: %v", err)
+	}
+	return parseAnswerHistory(string(data), path)
+}
+
+func parseAnswerHistory(data, path string) (*[]pastAnswer, error) {
+	answers := []pastAnswer{}
+	for idx, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.FieldsFunc(line, func(r rune) bool { return r == ' ' || r == '\t' || r == ',' })
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("Can't parse line %v of '%v', expected DATE WORD", idx+1, path)
+		}
+		date, err := time.Parse(dateFormat, fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("Can't parse date '%v' in line %v of '%v'", fields[0], idx+1, path)
+		}
+		answers = append(answers, pastAnswer{date, strings.ToUpper(fields[1])})
+	}
+	return &answers, nil
+}
+
+func pastAnswerWords(answers *[]pastAnswer) *[]string {
+	words := []string{}
+	for _, answer := range *answers {
+		words = append(words, answer.word)
+	}
+	return &words
+}
+
+func excludeWords(words *[]string, excluded *[]string) *[]string {
+	isExcluded := map[string]bool{}
+	for _, word := range *excluded {
+		isExcluded[strings.ToUpper(word)] = true
+	}
+	return applyToWordSlice(func(word string) string {
+		if isExcluded[strings.ToUpper(word)] {
+			return ""
+		}
+		return word
+	}, words)
+}
+
+// appendAnswer adds an answer to the history, creating the file if it
+// doesn't exist yet.
+func appendAnswer(path string, date time.Time, word string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	answers, err := parseAnswerHistory(string(data), path)
+	if err != nil {
+		return err
+	}
+	word = strings.ToUpper(word)
+	for _, answer := range *answers {
+		if answer.word == word {
+			return fmt.Errorf("'%v' was already the answer on %v", word, answer.date.Format(dateFormat))
+		}
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		if _, err := fmt.Fprintln(file); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(file, "%v %v\n", date.Format(dateFormat), word)
+	return err
+}
+
+// answerStatistics describes how much the past answers narrow down the
+// possible solutions.
+type answerStatistics struct {
+	answers   int
+	first     time.Time
+	last      time.Time
+	unknown   int
+	solutions int
+	remaining int
+}
+
+func computeAnswerStatistics(answers *[]pastAnswer, solutions *[]string) answerStatistics {
+	remaining := excludeWords(solutions, pastAnswerWords(answers))
+	isSolution := map[string]bool{}
+	for _, solution := range *solutions {
+		isSolution[strings.ToUpper(solution)] = true
+	}
+	unknown := map[string]bool{}
+	for _, answer := range *answers {
+		if !isSolution[answer.word] {
+			unknown[answer.word] = true
+		}
+	}
+	stats := answerStatistics{
+		answers:   len(*answers),
+		unknown:   len(unknown),
+		solutions: len(*solutions),
+		remaining: len(*remaining),
+	}
+	for idx, answer := range *answers {
+		if idx == 0 || answer.date.Before(stats.first) {
+			stats.first = answer.date
+		}
+		if idx == 0 || answer.date.After(stats.last) {
+			stats.last = answer.date
+		}
+	}
+	return stats
+}
+
+func (s answerStatistics) bits() float64 {
+	if s.remaining == 0 {
+		return 0
+	}
+	return math.Log2(float64(s.solutions) / float64(s.remaining))
+}
+
+func (s answerStatistics) report() *report {
+	r := newReport("answer_statistics", "past_answers", "first", "last", "unknown", "solutions", "remaining", "bits")
+	first, last := "", ""
+	if s.answers > 0 {
+		first, last = s.first.Format(dateFormat), s.last.Format(dateFormat)
+	}
+	r.add(s.answers, first, last, s.unknown, s.solutions, s.remaining, s.bits())
+	return r
+}
+
+func (s answerStatistics) print() {
+	fmt.Fprintf(messages, "Past answers: %v", s.answers)
+	if s.answers > 0 {
+		fmt.Fprintf(messages, " (%v to %v)", s.first.Format(dateFormat), s.last.Format(dateFormat))
+	}
+	removed := s.solutions - s.remaining
+	fmt.Fprintf(messages, "\nPast answers not in the solution list: %v\n", s.unknown)
+	fmt.Fprintf(messages, "Possible solutions: %v -> %v (%.1f%% removed)\n",
+		s.solutions, s.remaining, 100*float64(removed)/float64(s.solutions))
+	if s.remaining > 0 {
+		fmt.Fprintf(messages, "Information gained: %.3f bits\n", s.bits())
+	}
+}
+
+func answers(args []string) error {
+	flags := flag.NewFlagSet("answers", flag.ExitOnError)
+	path := flags.String("file", "answers.txt", "answer history `FILE`")
+	date := flags.String("date", time.Now().Format(dateFormat), "date of the answer to add")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: WordleSolver answers [flags] add WORD | stats")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	switch {
+	case flags.NArg() == 2 && flags.Arg(0) == "add":
+		day, err := time.Parse(dateFormat, *date)
+		if err != nil {
+			return fmt.Errorf("Can't parse date '%v'", *date)
+		}
+		word := strings.ToUpper(flags.Arg(1))
+		if !containsWord(applyToWordSlice(strings.ToUpper, &possibleSolutions), word) {
+			fmt.Fprintf(messages, "Warning: '%v' is not in the solution list\n", word)
+		}
+		return appendAnswer(*path, day, word)
+	case flags.NArg() == 1 && flags.Arg(0) == "stats":
+		history, err := readAnswerHistory(*path)
+		if err != nil {
+			return err
+		}
+		stats := computeAnswerStatistics(history, applyToWordSlice(strings.ToUpper, &possibleSolutions))
+		if machineOutput() {
+			return stats.report().emit()
+		}
+		stats.print()
+		return nil
+	}
+	flags.Usage()
+	return fmt.Errorf("Unknown answers command '%v'", strings.Join(flags.Args(), " "))
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReadAnswerHistory(t *testing.T) {
+	t.Run("with valid entries", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "answers.txt")
+		os.WriteFile(path, []byte("# past answers\n2022-02-01 wince\n\n2022-02-02,CIGAR\n"), 0644)
+		answers, err := readAnswerHistory(path)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		reference := []string{"WINCE", "CIGAR"}
+		compareWordSlices(t, pastAnswerWords(answers), &reference)
+		expectGotString(t, "2022-02-02", (*answers)[1].date.Format(dateFormat))
+	})
+
+	t.Run("with missing file", func(t *testing.T) {
+		if _, err := readAnswerHistory(filepath.Join(t.TempDir(), "answers.txt")); err == nil {
+			t.Errorf("Expected an error for a missing file")
+		}
+	})
+
+	t.Run("with invalid date", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "answers.txt")
+		os.WriteFile(path, []byte("yesterday WINCE\n"), 0644)
+		if _, err := readAnswerHistory(path); err == nil {
+			t.Errorf("Expected an error for an invalid date")
+		}
+	})
+}
+
+func TestAppendAnswer(t *testing.T) {
+	t.Run("with repeated answer", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "answers.txt")
+		date := time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)
+		if err := appendAnswer(path, date, "wince"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if err := appendAnswer(path, date.AddDate(0, 0, 1), "WINCE"); err == nil {
+			t.Errorf("Expected an error for a repeated answer")
+		}
+		answers, _ := readAnswerHistory(path)
+		compareWordSlices(t, pastAnswerWords(answers), &[]string{"WINCE"})
+	})
+
+	t.Run("without trailing newline", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "answers.txt")
+		os.WriteFile(path, []byte("2022-02-01 WINCE"), 0644)
+		if err := appendAnswer(path, time.Date(2022, 2, 2, 0, 0, 0, 0, time.UTC), "cigar"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		answers, err := readAnswerHistory(path)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		compareWordSlices(t, pastAnswerWords(answers), &[]string{"WINCE", "CIGAR"})
+	})
+}
+
+func TestComputeAnswerStatistics(t *testing.T) {
+	t.Run("counts unknown answers once", func(t *testing.T) {
+		day := time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)
+		answers := []pastAnswer{{day, "WINCE"}, {day.AddDate(0, 0, 1), "XYZZY"}, {day.AddDate(0, 0, 2), "WINCE"}, {day.AddDate(0, 0, 3), "XYZZY"}}
+		stats := computeAnswerStatistics(&answers, &[]string{"cigar", "wince"})
+		if stats.unknown != 1 || stats.remaining != 1 || stats.answers != 4 {
+			t.Errorf("Expected 1 unknown and 1 remaining of 4 answers got %+v", stats)
+		}
+		if !stats.first.Equal(day) || !stats.last.Equal(day.AddDate(0, 0, 3)) {
+			t.Errorf("Expected answers from %v to %v got %+v", day, day.AddDate(0, 0, 3), stats)
+		}
+	})
+}
+
+func TestExcludeWords(t *testing.T) {
+	t.Run("case insensitive", func(t *testing.T) {
+		words := []string{"abc", "ABD", "xyz"}
+		reference := []string{"ABD"}
+		compareWordSlices(t, excludeWords(&words, &[]string{"ABC", "xyz"}), &reference)
+	})
+}
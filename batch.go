@@ -0,0 +1,317 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"text/tabwriter"
+)
+
+// batchGame is a logged game, either with a known answer and the guesses, or
+// with the guesses and their scores.
+type batchGame struct {
+	line   int
+	answer string
+	turns  []sessionTurn
+	err    error
+}
+
+// parseBatchGame parses a game like "CRANE RAISE CLOUT CRANE", the answer
+// followed by the guesses, or like "RAISE:..h.. CLOUT:hh...", the guesses
+// with their scores.
+func parseBatchGame(line string) (batchGame, error) {
+	game := batchGame{turns: []sessionTurn{}}
+	fields := strings.FieldsFunc(line, func(r rune) bool { return r == ' ' || r == '\t' || r == ',' })
+	if strings.Contains(line, ":") {
+		for _, field := range fields {
+			parts := strings.Split(field, ":")
+			if len(parts) != 2 || len(parts[0]) != len(parts[1]) || strings.ContainsRune(parts[1], unknownTile) {
+				return game, fmt.Errorf("Can't parse guess and score '%v'", field)
+			}
+			game.turns = append(game.turns, sessionTurn{Guess: strings.ToUpper(parts[0]), Score: toUniqueScore(parts[1])})
+		}
+		return game, nil
+	}
+	if len(fields) < 2 {
+		return game, fmt.Errorf("Need an answer and at least one guess")
+	}
+	game.answer = strings.ToUpper(fields[0])
+	for _, guess := range fields[1:] {
+		game.turns = append(game.turns, sessionTurn{Guess: strings.ToUpper(guess)})
+	}
+	return game, nil
+}
+
+// readBatchGames reads a file with one game per line. Empty lines and lines
+// starting with '#' are ignored, lines that can't be parsed are returned
+// with their error.
+func readBatchGames(path string) ([]batchGame, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	games := []batchGame{}
+	for idx, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		game, err := parseBatchGame(line)
+		game.line, game.err = idx+1, err
+		games = append(games, game)
+	}
+	return games, nil
+}
+
+type batchResult struct {
+	game   batchGame
+	turns  []turnAnalysis
+	solved bool
+	err    error
+}
+
+func (r *batchResult) answer() string {
+	if r.game.answer != "" {
+		return r.game.answer
+	}
+	if r.solved {
+		return r.turns[len(r.turns)-1].guess
+	}
+	return "?"
+}
+
+// luck and skill are the averages over all turns of the game.
+func (r *batchResult) luck() float64 {
+	total := 0
+	for _, turn := range r.turns {
+		total += turn.luck
+	}
+	return float64(total) / float64(len(r.turns))
+}
+
+func (r *batchResult) skill() float64 {
+	total := 0
+	for _, turn := range r.turns {
+		total += turn.skill
+	}
+	return float64(total) / float64(len(r.turns))
+}
+
+// analyzeBatchGame replays a game like analyzeGame, using the baseline of
+// the first turn shared by all games.
+func analyzeBatchGame(wg *WordGame, game batchGame, opening turnBaseline) batchResult {
+	result := batchResult{game: game, turns: []turnAnalysis{}, err: game.err}
+	if result.err != nil {
+		return result
+	}
+	if game.answer != "" && !containsWord(wg.remainingWords, game.answer) {
+		result.err = fmt.Errorf("Can't analyze a game with unknown answer '%v'", game.answer)
+		return result
+	}
+	length := len((*wg.allWords)[0])
+	for idx, t := range game.turns {
+		if len(t.Guess) != length {
+			result.err = fmt.Errorf("Invalid length guess '%v'", t.Guess)
+			return result
+		}
+		score := t.Score
+		if game.answer != "" {
+			score = scoreAgainst(t.Guess, game.answer)
+		}
+		baseline := opening
+		if idx > 0 {
+			baseline = wg.baseline()
+		}
+		turn := wg.analyzeScoredTurn(t.Guess, score, baseline)
+		if turn.remainingAfter == 0 {
+			result.err = fmt.Errorf("No solution matches the scores up to guess %v", idx+1)
+			return result
+		}
+		result.turns = append(result.turns, *turn)
+		if score == strings.Repeat("H", length) {
+			result.solved = true
+			break
+		}
+	}
+	return result
+}
+
+// analyzeBatch analyzes the games concurrently, in the order of the games.
+func analyzeBatch(guesses, solutions *[]string, strategy string, games []batchGame, progress progressFunc) []batchResult {
+	newGame := func() *WordGame {
+		return &WordGame{allWords: guesses, remainingWords: solutions, strategy: strategy}
+	}
+	results := make([]batchResult, len(games))
+	if len(games) == 0 {
+		return results
+	}
+	opening := newGame().baseline()
+	done := int64(0)
+	parallelFor(len(games), func(idx int) {
+		results[idx] = analyzeBatchGame(newGame(), games[idx], opening)
+		if progress != nil {
+			progress(int(atomic.AddInt64(&done, 1)), len(games))
+		}
+	})
+	return results
+}
+
+type batchSummary struct {
+	games        int
+	solved       int
+	failed       int
+	invalid      int
+	distribution map[int]int
+	guesses      float64
+	luck         float64
+	skill        float64
+}
+
+func summarizeBatch(results []batchResult) batchSummary {
+	summary := batchSummary{games: len(results), distribution: map[int]int{}, guesses: math.NaN()}
+	totalGuesses, turns, totalLuck, totalSkill := 0, 0, 0, 0
+	for _, result := range results {
+		if result.err != nil {
+			summary.invalid += 1
+			continue
+		}
+		if result.solved {
+			summary.solved += 1
+			summary.distribution[len(result.turns)] += 1
+			totalGuesses += len(result.turns)
+		} else {
+			summary.failed += 1
+		}
+		for _, turn := range result.turns {
+			turns += 1
+			totalLuck += turn.luck
+			totalSkill += turn.skill
+		}
+	}
+	if summary.solved > 0 {
+		summary.guesses = float64(totalGuesses) / float64(summary.solved)
+	}
+	summary.luck, summary.skill = math.NaN(), math.NaN()
+	if turns > 0 {
+		summary.luck, summary.skill = float64(totalLuck)/float64(turns), float64(totalSkill)/float64(turns)
+	}
+	return summary
+}
+
+// orNull returns nil for NaN, so reports show missing averages as null.
+func orNull(value float64) interface{} {
+	if math.IsNaN(value) {
+		return nil
+	}
+	return value
+}
+
+func batchReports(results []batchResult, summary batchSummary) []*report {
+	games := newReport("batch_games", "line", "answer", "guesses", "solved", "luck", "skill", "error")
+	for _, result := range results {
+		if result.err != nil {
+			games.add(result.game.line, result.game.answer, nil, nil, nil, nil, result.err.Error())
+			continue
+		}
+		games.add(result.game.line, result.answer(), len(result.turns), result.solved, result.luck(), result.skill(), nil)
+	}
+	total := newReport("batch_summary", "games", "solved", "failed", "invalid", "average_guesses", "average_luck", "average_skill")
+	total.add(summary.games, summary.solved, summary.failed, summary.invalid,
+		orNull(summary.guesses), orNull(summary.luck), orNull(summary.skill))
+	distribution := newReport("batch_distribution", "guesses", "games")
+	for _, guesses := range summary.sortedGuesses() {
+		distribution.add(guesses, summary.distribution[guesses])
+	}
+	return []*report{games, total, distribution}
+}
+
+func (s batchSummary) sortedGuesses() []int {
+	guesses := []int{}
+	for count := range s.distribution {
+		guesses = append(guesses, count)
+	}
+	sort.Ints(guesses)
+	return guesses
+}
+
+func (s batchSummary) print() {
+	fmt.Fprintf(messages, "Games: %v, solved %v, failed %v, invalid %v\n", s.games, s.solved, s.failed, s.invalid)
+	if s.solved > 0 {
+		fmt.Fprintf(messages, "Average guesses: %.2f\n", s.guesses)
+		distribution := []string{}
+		for _, guesses := range s.sortedGuesses() {
+			distribution = append(distribution, fmt.Sprintf("%v: %v", guesses, s.distribution[guesses]))
+		}
+		fmt.Fprintf(messages, "Guess distribution: %v\n", strings.Join(distribution, ", "))
+	}
+	if !math.IsNaN(s.luck) {
+		fmt.Fprintf(messages, "Average luck: %.0f, average skill: %.0f\n", s.luck, s.skill)
+	}
+}
+
+func batch(args []string) error {
+	flags := flag.NewFlagSet("batch", flag.ExitOnError)
+	strategy := flags.String("strategy", defaultStrategy, "ranking strategy of the solver: "+strings.Join(strategyNames(), ", "))
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: WordleSolver batch [flags] FILE")
+		fmt.Fprintln(flags.Output(), "One game per line: 'ANSWER GUESS...' or 'GUESS:SCORE...'")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("Need a file of games")
+	}
+	if err := checkStrategy(*strategy); err != nil {
+		return err
+	}
+
+	games, err := readBatchGames(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	guesses, solutions, _ := canonicalWordLists(&allWords, &possibleSolutions, false)
+	fmt.Fprintf(messages, "Analyze %v games ...\n", len(games))
+	var progress progressFunc
+	if isTerminal(messages) {
+		progress = newProgressBar(messages).update
+	}
+	results := analyzeBatch(guesses, solutions, *strategy, games, progress)
+	summary := summarizeBatch(results)
+
+	if machineOutput() {
+		for _, r := range batchReports(results, summary) {
+			if err := r.emit(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	w := tabwriter.NewWriter(messages, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Line\tAnswer\tGuesses\tSolved\tLuck\tSkill")
+	for _, result := range results {
+		if result.err != nil {
+			continue
+		}
+		solved := "no"
+		if result.solved {
+			solved = "yes"
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%.0f\t%.0f\n",
+			result.game.line, result.answer(), len(result.turns), solved, result.luck(), result.skill())
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	for _, result := range results {
+		if result.err != nil {
+			fmt.Fprintf(messages, "Skipped line %v: %v\n", result.game.line, result.err)
+		}
+	}
+	summary.print()
+	return nil
+}
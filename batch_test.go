@@ -0,0 +1,106 @@
+package main
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseBatchGame(t *testing.T) {
+	t.Run("with answer and guesses", func(t *testing.T) {
+		game, err := parseBatchGame("crane raise, crane")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectGotString(t, "CRANE", game.answer)
+		if len(game.turns) != 2 || game.turns[1].Guess != "CRANE" || game.turns[1].Score != "" {
+			t.Errorf("Expected two guesses got %v", game.turns)
+		}
+	})
+
+	t.Run("with guesses and scores", func(t *testing.T) {
+		game, err := parseBatchGame("raise:..h.. count:hH.Hh")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectGotString(t, "", game.answer)
+		expectGotString(t, "COUNT", game.turns[1].Guess)
+		expectGotString(t, "hH.Hh", game.turns[1].Score)
+	})
+
+	t.Run("with invalid games", func(t *testing.T) {
+		for _, line := range []string{"crane", "raise:HHH", "raise:..?..", "raise:..h..:x"} {
+			if _, err := parseBatchGame(line); err == nil {
+				t.Errorf("Expected an error for '%v'", line)
+			}
+		}
+	})
+}
+
+func TestReadBatchGames(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "games.txt")
+	os.WriteFile(path, []byte("# games\nabc abd abc\n\nabd:HH\n"), 0644)
+	games, err := readBatchGames(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(games) != 2 || games[0].line != 2 || games[1].line != 4 {
+		t.Fatalf("Expected games in line 2 and 4 got %v", games)
+	}
+	if games[1].err == nil {
+		t.Errorf("Expected an error for a score of the wrong length")
+	}
+}
+
+func TestAnalyzeBatch(t *testing.T) {
+	guesses := []string{"ABC", "ABD", "XYZ", "EBC"}
+	solutions := []string{"ABC", "ABD", "EBC"}
+	games := []batchGame{
+		{line: 1, answer: "ABC", turns: []sessionTurn{{Guess: "ABD"}, {Guess: "ABC"}}},
+		{line: 2, turns: []sessionTurn{{Guess: "XYZ", Score: "..."}, {Guess: "EBC", Score: "HHH"}}},
+		{line: 3, turns: []sessionTurn{{Guess: "ABD", Score: "HH."}}},
+		{line: 4, answer: "XYZ", turns: []sessionTurn{{Guess: "ABC"}}},
+		{line: 5, turns: []sessionTurn{{Guess: "ABD", Score: "HHH"}, {Guess: "ABC", Score: "HHH"}}},
+	}
+	results := analyzeBatch(&guesses, &solutions, defaultStrategy, games, nil)
+
+	t.Run("analyzes every game", func(t *testing.T) {
+		if !results[0].solved || len(results[0].turns) != 2 {
+			t.Errorf("Expected game 1 solved in 2 guesses got %v", results[0])
+		}
+		expectGotString(t, "EBC", results[1].answer())
+		expectGotString(t, "?", results[2].answer())
+		if results[2].solved || results[2].err != nil {
+			t.Errorf("Expected game 3 to be unsolved got %v", results[2])
+		}
+		if results[3].err == nil {
+			t.Errorf("Expected an error for an unknown answer")
+		}
+		expectGotString(t, "ABD", results[4].answer())
+		if len(results[4].turns) != 1 {
+			t.Errorf("Expected game 5 to end with the solved score got %v", results[4].turns)
+		}
+	})
+
+	t.Run("summarizes the games", func(t *testing.T) {
+		summary := summarizeBatch(results)
+		if summary.games != 5 || summary.solved != 3 || summary.failed != 1 || summary.invalid != 1 {
+			t.Errorf("Expected 5 games, 3 solved, 1 failed, 1 invalid got %v", summary)
+		}
+		expectGotFloat(t, 5.0/3, summary.guesses)
+		if summary.distribution[1] != 1 || summary.distribution[2] != 2 {
+			t.Errorf("Expected one game in 1 and two in 2 guesses got %v", summary.distribution)
+		}
+	})
+
+	t.Run("without solved games", func(t *testing.T) {
+		summary := summarizeBatch(results[2:4])
+		if !math.IsNaN(summary.guesses) {
+			t.Errorf("Expected no average guesses got %v", summary.guesses)
+		}
+		if orNull(summary.guesses) != nil {
+			t.Errorf("Expected a missing average in the report")
+		}
+	})
+}
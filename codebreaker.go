@@ -0,0 +1,353 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Scorer scores guesses of a code breaking game. Wordle, Mastermind and
+// Bulls and Cows only differ in their scorer and in the codes they allow.
+type Scorer interface {
+	// Score returns the feedback for a guess against the secret code.
+	Score(guess, secret string) string
+	// ParseScore converts the feedback entered by the player into the form
+	// returned by Score.
+	ParseScore(input string) (string, error)
+}
+
+// wordleScorer scores guesses with green, yellow and grey tiles.
+type wordleScorer struct {
+	length int
+}
+
+func (s wordleScorer) Score(guess, secret string) string {
+	return scoreAgainst(guess, secret)
+}
+
+func (s wordleScorer) ParseScore(input string) (string, error) {
+	if len(input) != s.length {
+		return "", fmt.Errorf("Score '%v' doesn't have length %v", input, s.length)
+	}
+	return toUniqueScore(input), nil
+}
+
+// duplicateScorer scores ASCII words with green, yellow and grey tiles like
+// the official games: a repeated letter of the guess is only yellow as often
+// as the letter occurs in the secret besides the green tiles.
+type duplicateScorer struct {
+	wordleScorer
+}
+
+func (s duplicateScorer) Score(guess, secret string) string {
+	score := make([]byte, len(guess))
+	unmatched := [256]int8{}
+	for idx := 0; idx < len(guess); idx++ {
+		if guess[idx] == secret[idx] {
+			score[idx] = 'H'
+		} else {
+			score[idx] = '.'
+			unmatched[secret[idx]] += 1
+		}
+	}
+	for idx := 0; idx < len(guess); idx++ {
+		if score[idx] == '.' && unmatched[guess[idx]] > 0 {
+			score[idx] = 'h'
+			unmatched[guess[idx]] -= 1
+		}
+	}
+	return string(score)
+}
+
+// pegScorer scores guesses by the number of symbols at the right position,
+// the hits, and the number of further symbols in the code at another
+// position, the near hits. Mastermind calls them black and white pegs, Bulls
+// and Cows calls them bulls and cows. Scores look like "1B2W" or "12B0W", they
+// only match the same score.
+type pegScorer struct {
+	length int
+	hit    byte
+	near   byte
+}
+
+func (s pegScorer) Score(guess, secret string) string {
+	guessCounts, secretCounts := [128]int8{}, [128]int8{}
+	hits := 0
+	for idx := 0; idx < len(guess); idx++ {
+		if guess[idx] == secret[idx] {
+			hits += 1
+		} else {
+			guessCounts[guess[idx]] += 1
+			secretCounts[secret[idx]] += 1
+		}
+	}
+	near := 0
+	for symbol, count := range guessCounts {
+		if count > secretCounts[symbol] {
+			near += int(secretCounts[symbol])
+		} else {
+			near += int(count)
+		}
+	}
+	return s.format(hits, near)
+}
+
+// format writes the counts in decimal, so scores of ten or more pegs don't
+// turn into other symbols like the unknownTile.
+func (s pegScorer) format(hits, near int) string {
+	return strconv.Itoa(hits) + string(s.hit) + strconv.Itoa(near) + string(s.near)
+}
+
+// ParseScore accepts the counts of hits and near hits separated by a comma
+// or space ("1,2"), with their letters ("1B2W"), or one letter per peg
+// ("BWW"). An empty input means neither.
+func (s pegScorer) ParseScore(input string) (string, error) {
+	input = strings.ToUpper(strings.TrimSpace(input))
+	hits, near := 0, 0
+	if fields := strings.Fields(strings.Replace(input, ",", " ", -1)); len(fields) == 2 {
+		var err error
+		if hits, err = strconv.Atoi(fields[0]); err != nil {
+			return "", fmt.Errorf("Can't parse score '%v'", input)
+		}
+		if near, err = strconv.Atoi(fields[1]); err != nil {
+			return "", fmt.Errorf("Can't parse score '%v'", input)
+		}
+	} else {
+		count := -1
+		for idx := 0; idx < len(input); idx++ {
+			letter := input[idx]
+			if letter >= '0' && letter <= '9' {
+				if count < 0 {
+					count = 0
+				}
+				count = count*10 + int(letter-'0')
+				continue
+			}
+			if count < 0 {
+				count = 1
+			}
+			switch letter {
+			case s.hit:
+				hits += count
+			case s.near:
+				near += count
+			default:
+				return "", fmt.Errorf("Can't parse score '%v', expected counts of %c and %c", input, s.hit, s.near)
+			}
+			count = -1
+		}
+		if count >= 0 {
+			return "", fmt.Errorf("Can't parse score '%v', expected counts of %c and %c", input, s.hit, s.near)
+		}
+	}
+	if hits < 0 || near < 0 || hits+near > s.length {
+		return "", fmt.Errorf("Score '%v' has more than %v pegs", input, s.length)
+	}
+	return s.format(hits, near), nil
+}
+
+// allCodes returns all codes of the given length made of the symbols of the
+// alphabet, in alphabetical order if the alphabet is sorted.
+func allCodes(alphabet string, length int, repeats bool) *[]string {
+	codes := []string{""}
+	for position := 0; position < length; position++ {
+		longer := []string{}
+		for _, code := range codes {
+			for idx := 0; idx < len(alphabet); idx++ {
+				if repeats || strings.IndexByte(code, alphabet[idx]) < 0 {
+					longer = append(longer, code+alphabet[idx:idx+1])
+				}
+			}
+		}
+		codes = longer
+	}
+	return &codes
+}
+
+// maxCodes limits the number of codes of a game, as every code is rated
+// against every other code.
+const maxCodes = 50000
+
+// countCodes returns the number of codes allCodes returns, or maxCodes+1 if
+// there are more than maxCodes.
+func countCodes(symbols, length int, repeats bool) int {
+	count := 1
+	for position := 0; position < length; position++ {
+		choices := symbols
+		if !repeats {
+			choices -= position
+		}
+		if choices <= 0 {
+			return 0
+		}
+		count *= choices
+		if count > maxCodes {
+			return maxCodes + 1
+		}
+	}
+	return count
+}
+
+// createCodeBreakerGame returns a game over all codes scored by the scorer.
+func createCodeBreakerGame(scorer Scorer, codes *[]string) *WordGame {
+	return &WordGame{allWords: codes, remainingWords: codes, scorer: scorer}
+}
+
+// solve plays against a known secret and returns the guesses needed,
+// including the final one, or nil if the secret isn't a possible code.
+func (wg *WordGame) solve(secret string) []string {
+	solved := wg.score(secret, secret)
+	guesses := []string{}
+	for len(*wg.remainingWords) > 0 && len(guesses) < maxSimulatedTurns {
+		guess := wg.pickGuess()
+		guesses = append(guesses, guess)
+		score := wg.score(guess, secret)
+		if score == solved {
+			return guesses
+		}
+		wg.guess(guess, score)
+	}
+	return nil
+}
+
+// breakCode plays a code breaking game on the command line, or against a
+// known secret if one is given.
+func breakCode(wg *WordGame, suggestions int, secret string) error {
+	secret = strings.ToUpper(secret)
+	if secret != "" {
+		if !containsWord(wg.remainingWords, secret) {
+			return fmt.Errorf("'%v' isn't a possible code", secret)
+		}
+		r := newReport("game", "turn", "guess", "score")
+		for idx, guess := range wg.solve(secret) {
+			r.add(idx+1, guess, wg.score(guess, secret))
+			if !machineOutput() {
+				fmt.Fprintf(messages, "%v. %v %v\n", idx+1, guess, wg.score(guess, secret))
+			}
+		}
+		return r.emit()
+	}
+
+	if isTerminal(messages) {
+		wg.progress = newProgressBar(messages).update
+	}
+	fmt.Fprintf(messages, "Calculate best guesses for %v codes ...\n", len(*wg.remainingWords))
+	for len(*wg.remainingWords) > 1 {
+		weights := wg.getGuessWeights()
+		if wg.budget > 0 && len(weights) < len(*wg.allWords) {
+			fmt.Fprintf(messages, "Ranked %v of %v guesses within %v\n", len(weights), len(*wg.allWords), wg.budget)
+		}
+		bestGuesses := *getKeysSortedByWeight(&weights)
+		if len(bestGuesses) > suggestions {
+			bestGuesses = bestGuesses[:suggestions]
+		}
+		fmt.Fprintf(messages, "Best guesses: %v\n", bestGuesses)
+		if err := suggestionsReport(bestGuesses, weights).emit(); err != nil {
+			return err
+		}
+		guess := strings.ToUpper(readLine("Your guess: "))
+		if !containsWord(wg.allWords, guess) {
+			fmt.Fprintf(messages, "Invalid guess '%v'\n", guess)
+			continue
+		}
+		score, err := wg.scorer.ParseScore(readLine("Score of the guess: "))
+		for err != nil {
+			fmt.Fprintln(messages, err)
+			score, err = wg.scorer.ParseScore(readLine("Score of the guess: "))
+		}
+		wg.guess(guess, score)
+		fmt.Fprintf(messages, "Remaining codes: %v\n", len(*wg.remainingWords))
+		if err := remainingReport(wg.remainingWords).emit(); err != nil {
+			return err
+		}
+	}
+	if len(*wg.remainingWords) == 1 {
+		fmt.Fprintf(messages, "The solution is: %v\n", (*wg.remainingWords)[0])
+	} else {
+		fmt.Fprintln(messages, "No solution found :-(")
+	}
+	return solutionReport(wg.remainingWords).emit()
+}
+
+// codeBreakerOptions are the flags shared by all code breaking games.
+type codeBreakerOptions struct {
+	suggestions *int
+	strategy    *string
+	secret      *string
+	budget      *time.Duration
+	pool        *string
+}
+
+func codeBreakerFlags(flags *flag.FlagSet) *codeBreakerOptions {
+	return &codeBreakerOptions{
+		suggestions: flags.Int("suggestions", 12, "number of best guesses to show"),
+		strategy:    flags.String("strategy", defaultStrategy, "ranking strategy: "+strings.Join(strategyNames(), ", ")),
+		secret:      flags.String("secret", "", "play against the secret `CODE` instead of asking for scores"),
+		budget:      flags.Duration("budget", 0, "rank only as many guesses as possible within this time, e.g. 2s"),
+		pool:        flags.String("pool", "all", "guesses to rate: "+strings.Join(guessPoolModes, ", ")),
+	}
+}
+
+// play sets up the game with the options and plays it.
+func (o *codeBreakerOptions) play(wg *WordGame) error {
+	if err := checkStrategy(*o.strategy); err != nil {
+		return err
+	}
+	pool, err := parseGuessPool(*o.pool, false)
+	if err != nil {
+		return err
+	}
+	wg.strategy = *o.strategy
+	wg.budget = *o.budget
+	wg.pool = pool
+	return breakCode(wg, *o.suggestions, *o.secret)
+}
+
+func mastermind(args []string) error {
+	flags := flag.NewFlagSet("mastermind", flag.ExitOnError)
+	colors := flags.Int("colors", 6, "number of colors, named A, B, C, ...")
+	length := flags.Int("length", 4, "number of pegs in the code")
+	options := codeBreakerFlags(flags)
+	flags.Parse(args)
+	if *colors < 1 || *colors > 26 || *length < 1 {
+		return fmt.Errorf("Can't play with %v colors and %v pegs", *colors, *length)
+	}
+	if countCodes(*colors, *length, true) > maxCodes {
+		return fmt.Errorf("Can't play with %v colors and %v pegs, more than %v codes", *colors, *length, maxCodes)
+	}
+
+	codes := allCodes("ABCDEFGHIJKLMNOPQRSTUVWXYZ"[:*colors], *length, true)
+	return options.play(createCodeBreakerGame(pegScorer{length: *length, hit: 'B', near: 'W'}, codes))
+}
+
+func bullsAndCows(args []string) error {
+	flags := flag.NewFlagSet("bulls-and-cows", flag.ExitOnError)
+	length := flags.Int("length", 4, "number of different digits in the code")
+	options := codeBreakerFlags(flags)
+	flags.Parse(args)
+	if *length < 1 || *length > 10 {
+		return fmt.Errorf("Can't play with %v digits", *length)
+	}
+	if countCodes(10, *length, false) > maxCodes {
+		return fmt.Errorf("Can't play with %v digits, more than %v codes", *length, maxCodes)
+	}
+
+	codes := allCodes("0123456789", *length, false)
+	return options.play(createCodeBreakerGame(pegScorer{length: *length, hit: 'B', near: 'C'}, codes))
+}
+
+func wordle(args []string) error {
+	flags := flag.NewFlagSet("wordle", flag.ExitOnError)
+	options := codeBreakerFlags(flags)
+	flags.Parse(args)
+
+	solutions := applyToWordSlice(strings.ToUpper, &possibleSolutions)
+	wg := &WordGame{
+		allWords:       defaultGuesses(),
+		remainingWords: solutions,
+		scorer:         wordleScorer{length: dictionaryWordLength},
+	}
+	return options.play(wg)
+}
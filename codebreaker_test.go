@@ -0,0 +1,143 @@
+package main
+
+import "testing"
+
+func TestPegScorer(t *testing.T) {
+	s := pegScorer{length: 4, hit: 'B', near: 'W'}
+
+	t.Run("counts hits and near hits", func(t *testing.T) {
+		expectGotString(t, "1B2W", s.Score("ABCD", "ACEB"))
+		expectGotString(t, "0B0W", s.Score("AAAA", "BBBB"))
+	})
+
+	t.Run("counts repeated symbols only once", func(t *testing.T) {
+		expectGotString(t, "1B1W", s.Score("AABB", "ACCA"))
+		expectGotString(t, "2B0W", s.Score("AAAA", "AACC"))
+	})
+
+	t.Run("parses scores", func(t *testing.T) {
+		for input, reference := range map[string]string{
+			"1B2W": "1B2W",
+			"bww":  "1B2W",
+			"1,2":  "1B2W",
+			"1 2":  "1B2W",
+			"W":    "0B1W",
+			"":     "0B0W",
+		} {
+			score, err := s.ParseScore(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectGotString(t, reference, score)
+		}
+	})
+
+	t.Run("rejects invalid scores", func(t *testing.T) {
+		for _, input := range []string{"1X", "3B2W", "1,a", "2"} {
+			if _, err := s.ParseScore(input); err == nil {
+				t.Errorf("Expected an error for '%v'", input)
+			}
+		}
+	})
+
+	t.Run("with ten or more pegs", func(t *testing.T) {
+		long := pegScorer{length: 15, hit: 'B', near: 'W'}
+		score := long.Score("AAAAAAAAAAAAAAA", "AAAAAAAAAAAAAAA")
+		expectGotString(t, "15B0W", score)
+		parsed, err := long.ParseScore("15,0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectGotString(t, score, parsed)
+		if hasUnknownTiles(score) {
+			t.Errorf("Expected no unknown tile in '%v'", score)
+		}
+		if scoreMatches(long.Score("AAAAAAAAAAAAAAB", "AAAAAAAAAAAAAAA"), score, 0) {
+			t.Errorf("Expected 14B0W not to match %v", score)
+		}
+		wg := createCodeBreakerGame(long, &[]string{"AAAAAAAAAAAAAAA", "AAAAAAAAAAAAAAB", "BBBBBBBBBBBBBBB"})
+		wg.guess("AAAAAAAAAAAAAAA", score)
+		compareWordSlices(t, wg.remainingWords, &[]string{"AAAAAAAAAAAAAAA"})
+	})
+}
+
+func TestAllCodes(t *testing.T) {
+	t.Run("with repeats", func(t *testing.T) {
+		compareWordSlices(t, &[]string{"AA", "AB", "BA", "BB"}, allCodes("AB", 2, true))
+	})
+
+	t.Run("without repeats", func(t *testing.T) {
+		compareWordSlices(t, &[]string{"012", "021", "102", "120", "201", "210"}, allCodes("012", 3, false))
+	})
+}
+
+func TestCodeBreaker(t *testing.T) {
+	words := []string{"CRANE", "CRATE", "TRACE", "SLATE", "PLANT", "MOUSE", "HOUSE", "BLAST"}
+	games := []struct {
+		name     string
+		scorer   Scorer
+		codes    *[]string
+		maxTurns int
+	}{
+		{"mastermind", pegScorer{length: 3, hit: 'B', near: 'W'}, allCodes("ABCD", 3, true), 5},
+		{"bulls and cows", pegScorer{length: 3, hit: 'B', near: 'C'}, allCodes("01234", 3, false), 5},
+		{"wordle", wordleScorer{length: 5}, &words, 4},
+	}
+
+	for _, game := range games {
+		t.Run(game.name+" solves every code", func(t *testing.T) {
+			for _, secret := range *game.codes {
+				guesses := createCodeBreakerGame(game.scorer, game.codes).solve(secret)
+				if len(guesses) == 0 || len(guesses) > game.maxTurns || guesses[len(guesses)-1] != secret {
+					t.Errorf("Expected to find %v in %v guesses, got %v", secret, game.maxTurns, guesses)
+				}
+			}
+		})
+
+		t.Run(game.name+" keeps the matching codes", func(t *testing.T) {
+			wg := createCodeBreakerGame(game.scorer, game.codes)
+			guess, secret := (*game.codes)[0], (*game.codes)[len(*game.codes)-1]
+			wg.guess(guess, game.scorer.Score(guess, secret))
+			if !containsWord(wg.remainingWords, secret) || containsWord(wg.remainingWords, guess) {
+				t.Errorf("Expected %v to remain without %v, got %v", secret, guess, *wg.remainingWords)
+			}
+		})
+	}
+
+	t.Run("ranks like the word game", func(t *testing.T) {
+		cb := createCodeBreakerGame(wordleScorer{length: 5}, &words)
+		wg := createWordGameFromWordLists(&words, &words)
+		compareWordSlices(t, wg.getBestGuesses(), cb.getBestGuesses())
+	})
+}
+
+func TestCountCodes(t *testing.T) {
+	t.Run("counts the codes", func(t *testing.T) {
+		if got := countCodes(6, 4, true); got != 1296 {
+			t.Errorf("Expected 1296 codes got %v", got)
+		}
+		if got := countCodes(10, 4, false); got != 5040 {
+			t.Errorf("Expected 5040 codes got %v", got)
+		}
+		if got := countCodes(3, 4, false); got != 0 {
+			t.Errorf("Expected no codes got %v", got)
+		}
+	})
+
+	t.Run("stops above the limit", func(t *testing.T) {
+		for _, got := range []int{countCodes(26, 9, true), countCodes(10, 9, false)} {
+			if got != maxCodes+1 {
+				t.Errorf("Expected %v codes got %v", maxCodes+1, got)
+			}
+		}
+	})
+
+	t.Run("rejects too many codes", func(t *testing.T) {
+		if err := mastermind([]string{"-colors", "26", "-length", "9"}); err == nil {
+			t.Errorf("Expected an error for 26^9 codes")
+		}
+		if err := bullsAndCows([]string{"-length", "9"}); err == nil {
+			t.Errorf("Expected an error for 9 digits")
+		}
+	})
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// constraints describe partial knowledge about the solution, e.g.,
+// "A at 1, E present not at 5, no R S T" or "A...E +L -RST E=2".
+// Positions are stored zero based.
+type constraints struct {
+	fixed    map[int]rune
+	banned   map[int]string
+	required string
+	excluded string
+	patterns []string
+	counts   []letterCount
+}
+
+// letterCount restricts how often a letter occurs in a word, e.g., "E=2",
+// "E>=2" or "S<=1".
+type letterCount struct {
+	letter     rune
+	comparison string
+	count      int
+}
+
+func newConstraints() *constraints {
+	return &constraints{fixed: map[int]rune{}, banned: map[int]string{}}
+}
+
+func parseLetterCount(token string) (*letterCount, error) {
+	for _, comparison := range []string{">=", "<=", "="} {
+		idx := strings.Index(token, comparison)
+		if idx < 0 {
+			continue
+		}
+		letter, err := parseLetter(token[:idx])
+		if err != nil {
+			return nil, err
+		}
+		count, err := strconv.Atoi(token[idx+len(comparison):])
+		if err != nil || count < 0 {
+			return nil, fmt.Errorf("Can't use '%v' as a letter count", token)
+		}
+		return &letterCount{letter, comparison, count}, nil
+	}
+	return nil, fmt.Errorf("Can't use '%v' as a letter count", token)
+}
+
+func (lc *letterCount) matches(word string) bool {
+	count := strings.Count(word, string(lc.letter))
+	switch lc.comparison {
+	case ">=":
+		return count >= lc.count
+	case "<=":
+		return count <= lc.count
+	}
+	return count == lc.count
+}
+
+func isWildcard(letter rune) bool {
+	return letter == '.' || letter == '?' || letter == '_'
+}
+
+func parseLetters(tokens []string) (string, error) {
+	letters := ""
+	for _, token := range tokens {
+		for _, letter := range token {
+			if !isWordLetter(letter) {
+				return "", fmt.Errorf("Can't use '%v' as a letter", string(letter))
+			}
+		}
+		letters += token
+	}
+	return letters, nil
+}
+
+func parseLetter(token string) (rune, error) {
+	letters, err := parseLetters([]string{token})
+	if err != nil {
+		return 0, err
+	}
+	runes := []rune(letters)
+	if len(runes) != 1 {
+		return 0, fmt.Errorf("Expected a single letter, got '%v'", token)
+	}
+	return runes[0], nil
+}
+
+func parsePositions(tokens []string) ([]int, error) {
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("Expected at least one position")
+	}
+	positions := []int{}
+	for _, token := range tokens {
+		position, err := strconv.Atoi(token)
+		if err != nil || position < 1 {
+			return nil, fmt.Errorf("Can't use '%v' as a position", token)
+		}
+		positions = append(positions, position-1)
+	}
+	return positions, nil
+}
+
+func (c *constraints) parseClause(clause string) error {
+	tokens := strings.Fields(strings.ToUpper(clause))
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	switch {
+	case tokens[0] == "NO":
+		letters, err := parseLetters(tokens[1:])
+		if err != nil {
+			return err
+		}
+		c.excluded += letters
+		return nil
+	case len(tokens) >= 3 && tokens[1] == "AT":
+		letter, err := parseLetter(tokens[0])
+		if err != nil {
+			return err
+		}
+		positions, err := parsePositions(tokens[2:])
+		if err != nil {
+			return err
+		}
+		for _, position := range positions {
+			if fixed, ok := c.fixed[position]; ok && fixed != letter {
+				return fmt.Errorf("Can't have both %v and %v at %v", string(fixed), string(letter), position+1)
+			}
+			c.fixed[position] = letter
+		}
+		return nil
+	case len(tokens) >= 2 && (tokens[1] == "PRESENT" || tokens[1] == "NOT"):
+		letter, err := parseLetter(tokens[0])
+		if err != nil {
+			return err
+		}
+		rest := tokens[1:]
+		if rest[0] == "PRESENT" {
+			c.required += string(letter)
+			rest = rest[1:]
+		}
+		if len(rest) == 0 {
+			return nil
+		}
+		if len(rest) < 2 || rest[0] != "NOT" || rest[1] != "AT" {
+			return fmt.Errorf("Can't parse constraint '%v'", clause)
+		}
+		positions, err := parsePositions(rest[2:])
+		if err != nil {
+			return err
+		}
+		for _, position := range positions {
+			c.banned[position] += string(letter)
+		}
+		return nil
+	}
+
+	for _, token := range tokens {
+		if strings.HasPrefix(token, "+") || strings.HasPrefix(token, "-") {
+			letters, err := parseLetters([]string{token[1:]})
+			if err != nil {
+				return err
+			}
+			if token[0] == '+' {
+				c.required += letters
+			} else {
+				c.excluded += letters
+			}
+			continue
+		}
+		if strings.ContainsAny(token, "<=>") {
+			lc, err := parseLetterCount(token)
+			if err != nil {
+				return err
+			}
+			c.counts = append(c.counts, *lc)
+			continue
+		}
+		for _, letter := range token {
+			if !isWordLetter(letter) && !isWildcard(letter) {
+				return fmt.Errorf("Can't parse constraint '%v'", clause)
+			}
+		}
+		c.patterns = append(c.patterns, token)
+	}
+	return nil
+}
+
+func parseConstraints(input string) (*constraints, error) {
+	c := newConstraints()
+	for _, clause := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ';' }) {
+		if err := c.parseClause(clause); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func matchesPattern(word, pattern string) bool {
+	if len(word) != len(pattern) {
+		return false
+	}
+	for idx, letter := range pattern {
+		if !isWildcard(letter) && rune(word[idx]) != letter {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *constraints) matches(word string) bool {
+	for position, letter := range c.fixed {
+		if position >= len(word) || rune(word[position]) != letter {
+			return false
+		}
+	}
+	for position, letters := range c.banned {
+		if position < len(word) && strings.ContainsRune(letters, rune(word[position])) {
+			return false
+		}
+	}
+	for _, letter := range c.required {
+		if !strings.ContainsRune(word, letter) {
+			return false
+		}
+	}
+	if strings.ContainsAny(word, c.excluded) {
+		return false
+	}
+	for _, pattern := range c.patterns {
+		if !matchesPattern(word, pattern) {
+			return false
+		}
+	}
+	for _, lc := range c.counts {
+		if !lc.matches(word) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *constraints) filter(words *[]string) *[]string {
+	return applyToWordSlice(func(word string) string {
+		if c.matches(word) {
+			return word
+		}
+		return ""
+	}, words)
+}
+
+func (wg *WordGame) constrain(c *constraints) {
+	wg.remainingWords = c.filter(wg.remainingWords)
+}
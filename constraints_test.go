@@ -0,0 +1,97 @@
+package main
+
+import "testing"
+
+func TestParseConstraints(t *testing.T) {
+	t.Run("with natural language clauses", func(t *testing.T) {
+		c, err := parseConstraints("A at 1, E present not at 5, no R S T")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if c.fixed[0] != 'A' {
+			t.Errorf("Expected 'A' at position 0, got '%v'", string(c.fixed[0]))
+		}
+		expectGotString(t, "E", c.required)
+		expectGotString(t, "E", c.banned[4])
+		expectGotString(t, "RST", c.excluded)
+	})
+
+	t.Run("with pattern and letter sets", func(t *testing.T) {
+		c, err := parseConstraints("a..?e +l -rst")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		compareWordSlices(t, &c.patterns, &[]string{"A..?E"})
+		expectGotString(t, "L", c.required)
+		expectGotString(t, "RST", c.excluded)
+	})
+
+	t.Run("with invalid position", func(t *testing.T) {
+		if _, err := parseConstraints("A at first"); err == nil {
+			t.Errorf("Expected an error for an invalid position")
+		}
+	})
+
+	t.Run("with a repeated fixed letter", func(t *testing.T) {
+		c, err := parseConstraints("A at 1, A at 1 3")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if c.fixed[0] != 'A' || c.fixed[2] != 'A' {
+			t.Errorf("Expected 'A' at position 0 and 2, got %v", c.fixed)
+		}
+	})
+
+	t.Run("with conflicting fixed letters", func(t *testing.T) {
+		if _, err := parseConstraints("A at 1, B at 1"); err == nil {
+			t.Errorf("Expected an error for two letters at the same position")
+		}
+	})
+
+	t.Run("with several letters at a position", func(t *testing.T) {
+		if _, err := parseConstraints("AB at 1"); err == nil {
+			t.Errorf("Expected an error for two letters")
+		}
+	})
+
+	t.Run("with multibyte letters", func(t *testing.T) {
+		for _, input := range []string{"no Ä", "Ä at 1", "Ä...E"} {
+			if _, err := parseConstraints(input); err == nil {
+				t.Errorf("Expected an error for '%v'", input)
+			}
+		}
+	})
+
+	t.Run("with invalid pattern", func(t *testing.T) {
+		if _, err := parseConstraints("A*C"); err == nil {
+			t.Errorf("Expected an error for an invalid pattern")
+		}
+	})
+}
+
+func TestConstraintsFilter(t *testing.T) {
+	words := []string{"ABIDE", "ADORE", "ANGLE", "ALIKE", "ARISE"}
+
+	t.Run("with natural language clauses", func(t *testing.T) {
+		c, _ := parseConstraints("A at 1, E present, I not at 3, no R")
+		reference := []string{"ANGLE"}
+		compareWordSlices(t, c.filter(&words), &reference)
+	})
+
+	t.Run("with pattern", func(t *testing.T) {
+		c, _ := parseConstraints("A...E +L")
+		reference := []string{"ANGLE", "ALIKE"}
+		compareWordSlices(t, c.filter(&words), &reference)
+	})
+}
+
+func TestWordGameConstrain(t *testing.T) {
+	t.Run("are remaining words filtered", func(t *testing.T) {
+		words := []string{"ABC", "ACB", "EAD"}
+		wordGame := createWordGame(&words, 3)
+		c, _ := parseConstraints("A at 1, no E, C at 2")
+		wordGame.constrain(c)
+		reference := []string{"ACB"}
+		compareWordSlices(t, wordGame.remainingWords, &reference)
+	})
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// rejectedLine is a line of a word file that isn't a valid word.
+type rejectedLine struct {
+	line   int
+	word   string
+	reason string
+}
+
+// readWordFile returns the lines of a word file, without the line break at
+// the end of the file.
+func readWordFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.Replace(string(data), "\r\n", "\n", -1), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines, nil
+}
+
+// validateWords returns the uppercased valid words of the lines, and the
+// lines that were rejected with the reason.
+func validateWords(lines []string, length int) (*[]string, []rejectedLine) {
+	words := []string{}
+	rejected := []rejectedLine{}
+	seen := map[string]int{}
+	for idx, line := range lines {
+		word := strings.ToUpper(strings.TrimSpace(line))
+		reason := ""
+		if word == "" {
+			reason = "empty line"
+		} else if hasNoSpecialCharacters(word) == "" {
+			reason = "non-letter characters"
+		} else if hasLength(word, length) == "" {
+			reason = fmt.Sprintf("length %v instead of %v", utf8.RuneCountInString(word), length)
+		} else if first, ok := seen[word]; ok {
+			reason = fmt.Sprintf("duplicate of line %v", first)
+		}
+		if reason != "" {
+			rejected = append(rejected, rejectedLine{idx + 1, line, reason})
+			continue
+		}
+		seen[word] = idx + 1
+		words = append(words, word)
+	}
+	return &words, rejected
+}
+
+// loadWords returns the valid words of a word file, warning about the
+// rejected lines.
+func loadWords(path string, length int) (*[]string, error) {
+	lines, err := readWordFile(path)
+	if err != nil {
+		return nil, err
+	}
+	words, rejected := validateWords(lines, length)
+	if len(rejected) > 0 {
+		fmt.Fprintf(messages, "Warning: skipped %v invalid lines in '%v'\n", len(rejected), path)
+	}
+	return words, nil
+}
+
+func mergeWords(lists ...*[]string) *[]string {
+	seen := map[string]bool{}
+	merged := []string{}
+	for _, words := range lists {
+		for _, word := range *words {
+			if !seen[word] {
+				seen[word] = true
+				merged = append(merged, word)
+			}
+		}
+	}
+	sort.Strings(merged)
+	return &merged
+}
+
+// missingWords returns the words that aren't in the other list, sorted.
+func missingWords(words, other *[]string) *[]string {
+	known := map[string]bool{}
+	for _, word := range *other {
+		known[word] = true
+	}
+	missing := []string{}
+	for _, word := range *words {
+		if !known[word] {
+			missing = append(missing, word)
+		}
+	}
+	sort.Strings(missing)
+	return &missing
+}
+
+// commonWords returns the words that are in both lists, sorted.
+func commonWords(words, other *[]string) *[]string {
+	return missingWords(words, missingWords(words, other))
+}
+
+// writeWords writes the words in the format of the built-in lists: lowercase
+// and one per line.
+func writeWords(w io.Writer, words *[]string) error {
+	for _, word := range *words {
+		if _, err := fmt.Fprintln(w, strings.ToLower(word)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeWordsTo(path string, words *[]string) error {
+	if path == "" && machineOutput() {
+		r := newReport("words", "word")
+		for _, word := range *words {
+			r.add(strings.ToLower(word))
+		}
+		return r.emit()
+	}
+	if path == "" {
+		return writeWords(messages, words)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return writeWords(file, words)
+}
+
+func dict(args []string) error {
+	flags := flag.NewFlagSet("dict", flag.ExitOnError)
+	length := flags.Int("length", 5, "length of the words")
+	output := flags.String("output", "", "write merged or normalized words to `FILE` instead of stdout")
+	further := flags.Bool("further", false, "check GUESSES holds only the further guesses, like guesses.txt of the built-in lists")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: WordleSolver dict [flags] list | validate FILE... | merge FILE... | diff OLD NEW | check GUESSES SOLUTIONS | normalize FILE")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	paths := flags.Args()
+	if len(paths) > 0 {
+		paths = paths[1:]
+	}
+	lists := []*[]string{}
+	load := func() error {
+		for _, path := range paths {
+			words, err := loadWords(path, *length)
+			if err != nil {
+				return err
+			}
+			lists = append(lists, words)
+		}
+		return nil
+	}
+
+	switch {
+	case flags.Arg(0) == "list" && len(paths) == 0:
+		return printDictionaries(messages)
+	case flags.Arg(0) == "validate" && len(paths) > 0:
+		invalid := 0
+		files := newReport("word_files", "file", "words", "rejected")
+		lines := newReport("rejected_lines", "file", "line", "word", "reason")
+		for _, path := range paths {
+			content, err := readWordFile(path)
+			if err != nil {
+				return err
+			}
+			words, rejected := validateWords(content, *length)
+			for _, r := range rejected {
+				lines.add(path, r.line, r.word, r.reason)
+				if !machineOutput() {
+					fmt.Fprintf(messages, "%v:%v: '%v' %v\n", path, r.line, r.word, r.reason)
+				}
+			}
+			files.add(path, len(*words), len(rejected))
+			if !machineOutput() {
+				fmt.Fprintf(messages, "%v: %v words, %v rejected lines\n", path, len(*words), len(rejected))
+			}
+			invalid += len(rejected)
+		}
+		if err := files.emit(); err != nil {
+			return err
+		}
+		if err := lines.emit(); err != nil {
+			return err
+		}
+		if invalid > 0 {
+			return fmt.Errorf("Found %v invalid lines", invalid)
+		}
+		return nil
+	case flags.Arg(0) == "merge" && len(paths) > 0:
+		if err := load(); err != nil {
+			return err
+		}
+		return writeWordsTo(*output, mergeWords(lists...))
+	case flags.Arg(0) == "normalize" && len(paths) == 1:
+		if err := load(); err != nil {
+			return err
+		}
+		return writeWordsTo(*output, mergeWords(lists[0]))
+	case flags.Arg(0) == "diff" && len(paths) == 2:
+		if err := load(); err != nil {
+			return err
+		}
+		r := newReport("diff", "change", "word")
+		for _, word := range *missingWords(lists[0], lists[1]) {
+			r.add("-", strings.ToLower(word))
+		}
+		for _, word := range *missingWords(lists[1], lists[0]) {
+			r.add("+", strings.ToLower(word))
+		}
+		if machineOutput() {
+			return r.emit()
+		}
+		for _, row := range r.rows {
+			fmt.Fprintf(messages, "%v%v\n", row[0], row[1])
+		}
+		return nil
+	case flags.Arg(0) == "check" && len(paths) == 2 && *further:
+		if err := load(); err != nil {
+			return err
+		}
+		common := commonWords(lists[1], lists[0])
+		r := newReport("repeated_solutions", "word")
+		for _, word := range *common {
+			r.add(strings.ToLower(word))
+			if !machineOutput() {
+				fmt.Fprintf(messages, "Solution '%v' is also a further guess\n", strings.ToLower(word))
+			}
+		}
+		if err := r.emit(); err != nil {
+			return err
+		}
+		if len(*common) > 0 {
+			return fmt.Errorf("%v of %v solutions are also further guesses", len(*common), len(*lists[1]))
+		}
+		fmt.Fprintf(messages, "None of the %v solutions is a further guess\n", len(*lists[1]))
+		return nil
+	case flags.Arg(0) == "check" && len(paths) == 2:
+		if err := load(); err != nil {
+			return err
+		}
+		missing := missingWords(lists[1], lists[0])
+		r := newReport("missing_solutions", "word")
+		for _, word := range *missing {
+			r.add(strings.ToLower(word))
+			if !machineOutput() {
+				fmt.Fprintf(messages, "Solution '%v' is not a valid guess\n", strings.ToLower(word))
+			}
+		}
+		if err := r.emit(); err != nil {
+			return err
+		}
+		if len(*missing) > 0 && len(*missing) == len(*lists[1]) {
+			fmt.Fprintln(messages, "None of the solutions is a guess, use -further if the guesses are only the further guesses")
+		}
+		if len(*missing) > 0 {
+			return fmt.Errorf("%v of %v solutions are not valid guesses", len(*missing), len(*lists[1]))
+		}
+		fmt.Fprintf(messages, "All %v solutions are valid guesses\n", len(*lists[1]))
+		return nil
+	}
+	flags.Usage()
+	return fmt.Errorf("Unknown dict command '%v'", strings.Join(flags.Args(), " "))
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadWordFile(t *testing.T) {
+	t.Run("without the final line break", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "words.txt")
+		os.WriteFile(path, []byte("crane\r\nslate\n\ntrace\n"), 0644)
+		lines, err := readWordFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		compareWordSlices(t, &[]string{"crane", "slate", "", "trace"}, &lines)
+	})
+}
+
+func TestValidateWords(t *testing.T) {
+	lines := []string{"crane", " Slate", "cr4ne", "cranes", "", "CRANE"}
+	words, rejected := validateWords(lines, 5)
+
+	t.Run("keeps valid words", func(t *testing.T) {
+		compareWordSlices(t, &[]string{"CRANE", "SLATE"}, words)
+	})
+
+	t.Run("reports rejected lines", func(t *testing.T) {
+		reference := []rejectedLine{
+			{3, "cr4ne", "non-letter characters"},
+			{4, "cranes", "length 6 instead of 5"},
+			{5, "", "empty line"},
+			{6, "CRANE", "duplicate of line 1"},
+		}
+		if len(rejected) != len(reference) {
+			t.Fatalf("Expected %v got %v", reference, rejected)
+		}
+		for idx, r := range rejected {
+			if r != reference[idx] {
+				t.Errorf("Expected %v got %v", reference[idx], r)
+			}
+		}
+	})
+}
+
+func TestLoadWords(t *testing.T) {
+	t.Run("warns about rejected lines on the messages", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "words.txt")
+		os.WriteFile(path, []byte("crane\ncr4ne\n"), 0644)
+		var buffer bytes.Buffer
+		previous := messages
+		messages = &buffer
+		defer func() { messages = previous }()
+		words, err := loadWords(path, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		compareWordSlices(t, &[]string{"CRANE"}, words)
+		expectGotString(t, "Warning: skipped 1 invalid lines in '"+path+"'\n", buffer.String())
+	})
+}
+
+func TestMergeAndDiff(t *testing.T) {
+	first := []string{"SLATE", "CRANE"}
+	second := []string{"TRACE", "CRANE"}
+
+	t.Run("merge", func(t *testing.T) {
+		compareWordSlices(t, &[]string{"CRANE", "SLATE", "TRACE"}, mergeWords(&first, &second))
+	})
+
+	t.Run("missing words", func(t *testing.T) {
+		compareWordSlices(t, &[]string{"SLATE"}, missingWords(&first, &second))
+		compareWordSlices(t, &[]string{}, missingWords(&first, &first))
+	})
+
+	t.Run("common words", func(t *testing.T) {
+		compareWordSlices(t, &[]string{"CRANE"}, commonWords(&first, &second))
+		compareWordSlices(t, &[]string{}, commonWords(&first, &[]string{"TRACE"}))
+	})
+
+	t.Run("write lowercase", func(t *testing.T) {
+		var buffer bytes.Buffer
+		writeWords(&buffer, &first)
+		expectGotString(t, "slate\ncrane\n", buffer.String())
+	})
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// The built-in word lists are embedded from dictionaries/NAME/, with the
+// possible solutions in solutions.txt and the further allowed guesses in
+// guesses.txt, one lowercase word per line.
+//
+//go:embed dictionaries
+var dictionaryFiles embed.FS
+
+const defaultDictionary = "original-2022"
+
+type builtinDictionary struct {
+	name      string
+	guesses   *[]string
+	solutions *[]string
+	length    int
+}
+
+func builtinDictionaryNames() []string {
+	entries, _ := dictionaryFiles.ReadDir("dictionaries")
+	names := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func readEmbeddedWords(path string) (*[]string, error) {
+	data, err := fs.ReadFile(dictionaryFiles, path)
+	if err != nil {
+		return nil, err
+	}
+	words := strings.Fields(string(data))
+	return &words, nil
+}
+
+func loadBuiltinDictionary(name string) (*builtinDictionary, error) {
+	names := builtinDictionaryNames()
+	if !containsWord(&names, name) {
+		return nil, fmt.Errorf("Unknown dictionary '%v', expected one of %v", name, strings.Join(names, ", "))
+	}
+	guesses, err := readEmbeddedWords("dictionaries/" + name + "/guesses.txt")
+	if err != nil {
+		return nil, err
+	}
+	solutions, err := readEmbeddedWords("dictionaries/" + name + "/solutions.txt")
+	if err != nil {
+		return nil, err
+	}
+	if len(*solutions) == 0 {
+		return nil, fmt.Errorf("Can't use dictionary '%v' without solutions", name)
+	}
+	length := len((*solutions)[0])
+	for _, words := range []*[]string{guesses, solutions} {
+		if err := checkDictionaryWords(name, words, length); err != nil {
+			return nil, err
+		}
+	}
+	return &builtinDictionary{name, guesses, solutions, length}, nil
+}
+
+// checkDictionaryWords checks that all words of a built-in list have the
+// length of its first solution and only the letters A to Z.
+func checkDictionaryWords(name string, words *[]string, length int) error {
+	for _, word := range *words {
+		if len(word) != length || hasNoSpecialCharacters(word) == "" {
+			return fmt.Errorf("Can't use dictionary '%v', the word '%v' doesn't have %v letters", name, word, length)
+		}
+	}
+	return nil
+}
+
+// currentDictionary is the name of the built-in lists in allWords and
+// possibleSolutions, which all commands play with, and dictionaryWordLength
+// the length of their words.
+var currentDictionary string
+var allWords, possibleSolutions []string
+var dictionaryWordLength int
+
+func init() {
+	if err := useDictionary(defaultDictionary); err != nil {
+		panic(err)
+	}
+}
+
+func useDictionary(name string) error {
+	d, err := loadBuiltinDictionary(name)
+	if err != nil {
+		return err
+	}
+	currentDictionary, allWords, possibleSolutions = d.name, *d.guesses, *d.solutions
+	dictionaryWordLength = d.length
+	return nil
+}
+
+func dictionariesReport() (*report, error) {
+	r := newReport("dictionaries", "name", "length", "solutions", "guesses", "current")
+	for _, name := range builtinDictionaryNames() {
+		d, err := loadBuiltinDictionary(name)
+		if err != nil {
+			return nil, err
+		}
+		r.add(d.name, d.length, len(*d.solutions), len(*d.guesses), name == currentDictionary)
+	}
+	return r, nil
+}
+
+func printDictionaries(w io.Writer) error {
+	if machineOutput() {
+		r, err := dictionariesReport()
+		if err != nil {
+			return err
+		}
+		return r.emit()
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Name\tLength\tSolutions\tGuesses\t")
+	for _, name := range builtinDictionaryNames() {
+		d, err := loadBuiltinDictionary(name)
+		if err != nil {
+			return err
+		}
+		current := ""
+		if name == currentDictionary {
+			current = "(current)"
+		}
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\n", d.name, d.length, len(*d.solutions), len(*d.guesses), current)
+	}
+	return tw.Flush()
+}
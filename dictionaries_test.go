@@ -0,0 +1,82 @@
+package main
+
+import "testing"
+
+func TestBuiltinDictionaries(t *testing.T) {
+	t.Run("default dictionary", func(t *testing.T) {
+		d, err := loadBuiltinDictionary(defaultDictionary)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(*d.solutions) != 2315 || len(*d.guesses) != 10657 || d.length != 5 {
+			t.Errorf("Expected 2315 solutions and 10657 guesses of length 5, got %v, %v and %v", len(*d.solutions), len(*d.guesses), d.length)
+		}
+		expectGotString(t, "aback", (*d.solutions)[0])
+	})
+
+	t.Run("is listed", func(t *testing.T) {
+		names := builtinDictionaryNames()
+		if !containsWord(&names, defaultDictionary) {
+			t.Errorf("Expected %v in %v", defaultDictionary, names)
+		}
+	})
+
+	t.Run("unknown dictionary", func(t *testing.T) {
+		if _, err := loadBuiltinDictionary("klingon-5"); err == nil {
+			t.Errorf("Expected an error for an unknown dictionary")
+		}
+	})
+
+	t.Run("only words of the same length", func(t *testing.T) {
+		if err := checkDictionaryWords("test", &[]string{"crane", "slate"}, 5); err != nil {
+			t.Error(err)
+		}
+		if err := checkDictionaryWords("test", &[]string{"cranes", "slated"}, 6); err != nil {
+			t.Error(err)
+		}
+		for _, word := range []string{"cranes", "cat", "äpfel"} {
+			if err := checkDictionaryWords("test", &[]string{"crane", word}, 5); err == nil {
+				t.Errorf("Expected an error for '%v'", word)
+			}
+		}
+	})
+}
+
+func TestGlobalOptions(t *testing.T) {
+	for _, args := range [][]string{
+		{"-dictionary", defaultDictionary, "grep", "CR.NE"},
+		{"--dictionary=" + defaultDictionary, "grep", "CR.NE"},
+		{"grep", "CR.NE"},
+	} {
+		remaining, err := parseGlobalOptions(args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		compareWordSlices(t, &[]string{"grep", "CR.NE"}, &remaining)
+	}
+
+	t.Run("several options", func(t *testing.T) {
+		remaining, err := parseGlobalOptions([]string{"-dictionary", defaultDictionary, "-output=text", "-suggestions", "3"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		compareWordSlices(t, &[]string{"-suggestions", "3"}, &remaining)
+	})
+
+	t.Run("format as alias of output", func(t *testing.T) {
+		defer useOutput("text")
+		remaining, err := parseGlobalOptions([]string{"-format", "json", "grep", "CR.NE"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		compareWordSlices(t, &[]string{"grep", "CR.NE"}, &remaining)
+		expectGotString(t, "json", outputFormat)
+	})
+
+	t.Run("unknown dictionary", func(t *testing.T) {
+		if _, err := parseGlobalOptions([]string{"-dictionary", "klingon-5"}); err == nil {
+			t.Errorf("Expected an error for an unknown dictionary")
+		}
+		expectGotString(t, defaultDictionary, currentDictionary)
+	})
+}
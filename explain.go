@@ -0,0 +1,144 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+type scoreBucket struct {
+	score string
+	words []string
+}
+
+// guessExplanation shows how a guess partitions the remaining words. The
+// buckets are sorted by size, so the first one determines the minimax weight.
+type guessExplanation struct {
+	guess   string
+	buckets []scoreBucket
+	entropy float64
+}
+
+// scoreBuckets groups the remaining words by the scores the guess can get,
+// like scoreDistribution. With lies a word is in the bucket of every score
+// that could be reported for it.
+func (wg *WordGame) scoreBuckets(guess string) map[string][]string {
+	buckets := map[string][]string{}
+	for _, solution := range *wg.remainingWords {
+		score := wg.score(guess, solution)
+		if wg.lies == 0 {
+			buckets[score] = append(buckets[score], solution)
+			continue
+		}
+		for _, reported := range *lyingScores(score, wg.lies) {
+			buckets[reported] = append(buckets[reported], solution)
+		}
+	}
+	return buckets
+}
+
+func (wg *WordGame) explain(guess string) (*guessExplanation, error) {
+	if len(guess) != wg.wordLength() {
+		return nil, fmt.Errorf("Invalid length guess '%v'", guess)
+	}
+	explanation := &guessExplanation{guess: guess}
+	distribution := map[string]int{}
+	for score, words := range wg.scoreBuckets(guess) {
+		explanation.buckets = append(explanation.buckets, scoreBucket{score, words})
+		distribution[score] = len(words)
+	}
+	sort.Slice(explanation.buckets, func(i, j int) bool {
+		a, b := explanation.buckets[i], explanation.buckets[j]
+		if len(a.words) != len(b.words) {
+			return len(a.words) > len(b.words)
+		}
+		return a.score < b.score
+	})
+	explanation.entropy = entropy(distribution)
+	return explanation, nil
+}
+
+func (e *guessExplanation) weight() int {
+	if len(e.buckets) == 0 {
+		return 0
+	}
+	return len(e.buckets[0].words)
+}
+
+func (e *guessExplanation) print(w io.Writer, examples int) {
+	fmt.Fprintf(w, "Guess %v: minimax weight %v, entropy %.2f bits, %v buckets\n",
+		e.guess, e.weight(), e.entropy, len(e.buckets))
+	for idx, bucket := range e.buckets {
+		shown := bucket.words
+		if len(shown) > examples {
+			shown = shown[:examples]
+		}
+		marker := ""
+		if idx == 0 {
+			marker = " <- worst case"
+		}
+		fmt.Fprintf(w, "  %v %5v  %v%v\n", bucket.score, len(bucket.words), strings.Join(shown, " "), marker)
+	}
+}
+
+// report lists the buckets of the explanation with all their words.
+func (e *guessExplanation) report() *report {
+	r := newReport("explanation", "guess", "score", "count", "words")
+	for _, bucket := range e.buckets {
+		r.add(e.guess, bucket.score, len(bucket.words), bucket.words)
+	}
+	return r
+}
+
+// parseHistory parses guesses with their scores, e.g. "AESIR:.h.h.,CLINE:h.hhH",
+// of words with the given length.
+func parseHistory(input string, length int) (*[]string, *[]string, error) {
+	guesses, scores := []string{}, []string{}
+	for _, turn := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' }) {
+		parts := strings.Split(turn, ":")
+		if len(parts) != 2 || len(parts[0]) != len(parts[1]) {
+			return nil, nil, fmt.Errorf("Can't parse turn '%v', expected GUESS:SCORE", turn)
+		}
+		if len(parts[0]) != length {
+			return nil, nil, fmt.Errorf("Invalid length guess '%v' in turn '%v'", parts[0], turn)
+		}
+		guesses = append(guesses, strings.ToUpper(parts[0]))
+		scores = append(scores, toUniqueScore(parts[1]))
+	}
+	return &guesses, &scores, nil
+}
+
+func explain(args []string) error {
+	flags := flag.NewFlagSet("explain", flag.ExitOnError)
+	history := flags.String("history", "", "previous turns, e.g. 'AESIR:.h.h.,CLINE:h.hhH'")
+	examples := flags.Int("examples", 5, "number of example words per bucket")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: WordleSolver explain [flags] GUESS")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("Need exactly one guess to explain")
+	}
+
+	wg := createDefaultWordGame()
+	guesses, scores, err := parseHistory(*history, wg.wordLength())
+	if err != nil {
+		return err
+	}
+	for idx, guess := range *guesses {
+		wg.guess(guess, (*scores)[idx])
+	}
+	explanation, err := wg.explain(strings.ToUpper(flags.Arg(0)))
+	if err != nil {
+		return err
+	}
+	if machineOutput() {
+		return explanation.report().emit()
+	}
+	explanation.print(messages, *examples)
+	return nil
+}
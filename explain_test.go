@@ -0,0 +1,69 @@
+package main
+
+import "testing"
+
+func TestWordGameExplain(t *testing.T) {
+	t.Run("with simple word list", func(t *testing.T) {
+		words := []string{"AXY", "BXY", "CXY", "ABC"}
+		wordGame := createWordGame(&words, 3)
+		explanation, err := wordGame.explain("AXY")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if explanation.weight() != 2 {
+			t.Errorf("Expected weight '2' got '%v'", explanation.weight())
+		}
+		expectGotString(t, ".HH", explanation.buckets[0].score)
+		compareWordSlices(t, &explanation.buckets[0].words, &[]string{"BXY", "CXY"})
+		expectGotFloat(t, 1.5, explanation.entropy)
+	})
+
+	t.Run("with lies", func(t *testing.T) {
+		words := []string{"AXY", "BXY", "CXY", "ABC"}
+		wordGame := createWordGame(&words, 3)
+		wordGame.lies = 1
+		explanation, err := wordGame.explain("AXY")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		distribution := wordGame.scoreDistribution("AXY")
+		if len(explanation.buckets) != len(distribution) {
+			t.Errorf("Expected %v buckets got %v", len(distribution), len(explanation.buckets))
+		}
+		for _, bucket := range explanation.buckets {
+			if len(bucket.words) != distribution[bucket.score] {
+				t.Errorf("Expected %v words for %v got %v", distribution[bucket.score], bucket.score, bucket.words)
+			}
+		}
+	})
+
+	t.Run("with invalid length guess", func(t *testing.T) {
+		words := []string{"AXY", "BXY"}
+		if _, err := createWordGame(&words, 3).explain("AB"); err == nil {
+			t.Errorf("Expected an error for a guess of the wrong length")
+		}
+	})
+}
+
+func TestParseHistory(t *testing.T) {
+	t.Run("with valid turns", func(t *testing.T) {
+		guesses, scores, err := parseHistory("aesir:.h.h., cline:h.hhH", 5)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		compareWordSlices(t, guesses, &[]string{"AESIR", "CLINE"})
+		compareWordSlices(t, scores, &[]string{".h.h.", "h.hhH"})
+	})
+
+	t.Run("with mismatching score length", func(t *testing.T) {
+		if _, _, err := parseHistory("aesir:.h.", 5); err == nil {
+			t.Errorf("Expected an error for a mismatching score")
+		}
+	})
+
+	t.Run("with wrong word length", func(t *testing.T) {
+		if _, _, err := parseHistory("ab:..", 5); err == nil {
+			t.Errorf("Expected an error for a guess of the wrong length")
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package main
+
+// In Fibble, every score contains a fixed number of lies, tiles with a
+// wrong color. A game with lies keeps every word whose true score differs
+// from the reported score in exactly that many positions.
+
+var scoreColors = []byte{'.', 'h', 'H'}
+
+// scoreDifferences returns the number of positions in which the reported
+// score differs from the true score, and the number of unknown positions.
+func scoreDifferences(score, reported string) (int, int) {
+	differences, unknown := 0, 0
+	for idx := 0; idx < len(score); idx++ {
+		if reported[idx] == unknownTile {
+			unknown += 1
+		} else if score[idx] != reported[idx] {
+			differences += 1
+		}
+	}
+	return differences, unknown
+}
+
+// scoreMatches reports whether a word with the true score is compatible
+// with the reported score. Any number of the lies might be hidden in
+// unknown positions. Scores of different lengths never match.
+func scoreMatches(score, reported string, lies int) bool {
+	if len(score) != len(reported) {
+		return false
+	}
+	differences, unknown := scoreDifferences(score, reported)
+	return differences <= lies && differences >= lies-unknown
+}
+
+// lyingScores returns all scores that differ from the true score in exactly
+// the given number of positions.
+func lyingScores(score string, lies int) *[]string {
+	scores := []string{}
+	var lie func(reported []byte, from, lies int)
+	lie = func(reported []byte, from, lies int) {
+		if lies == 0 {
+			scores = append(scores, string(reported))
+			return
+		}
+		for idx := from; idx <= len(reported)-lies; idx++ {
+			truth := reported[idx]
+			for _, color := range scoreColors {
+				if color != truth {
+					reported[idx] = color
+					lie(reported, idx+1, lies-1)
+				}
+			}
+			reported[idx] = truth
+		}
+	}
+	lie([]byte(score), 0, lies)
+	return &scores
+}
@@ -0,0 +1,43 @@
+package main
+
+import "testing"
+
+func TestLyingScores(t *testing.T) {
+	t.Run("with one lie", func(t *testing.T) {
+		reference := []string{"h.", "H.", ".h", ".H"}
+		compareWordSlices(t, lyingScores("..", 1), &reference)
+	})
+
+	t.Run("with two lies", func(t *testing.T) {
+		if got := len(*lyingScores("H.h", 2)); got != 12 {
+			t.Errorf("Expected 12 scores got %v", got)
+		}
+	})
+
+	t.Run("without lies", func(t *testing.T) {
+		compareWordSlices(t, lyingScores("H.h", 0), &[]string{"H.h"})
+	})
+}
+
+func TestWordGameGuessWithLies(t *testing.T) {
+	t.Run("keeps words with exactly one difference", func(t *testing.T) {
+		words := []string{"ABC", "ACB", "EAD", "AEF"}
+		wordGame := createWordGame(&words, 3)
+		wordGame.lies = 1
+		wordGame.guess("AEF", "...")
+		reference := []string{"ABC", "ACB"}
+		compareWordSlices(t, wordGame.remainingWords, &reference)
+	})
+}
+
+func TestScoreDistributionWithLies(t *testing.T) {
+	t.Run("counts every possible report", func(t *testing.T) {
+		wordGame := createWordGame(&[]string{"AB", "CD"}, 2)
+		wordGame.lies = 1
+		distribution := wordGame.scoreDistribution("AB")
+		if distribution["H."] != 2 || distribution["HH"] != 0 || distribution["h."] != 1 {
+			t.Errorf("Unexpected distribution %v", distribution)
+		}
+		expectGotFloat(t, 2, minimaxWeight(distribution))
+	})
+}
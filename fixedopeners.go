@@ -0,0 +1,325 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+)
+
+// maxEncodedLength is the longest word whose encoded scores are looked up in
+// tables, longer words would need tables of millions of entries.
+const maxEncodedLength = 6
+
+// countScoreCodes returns the number of different scores of a word of the
+// given length, the codes of encodeScore are below it.
+func countScoreCodes(length int) int {
+	codes := 1
+	for idx := 0; idx < length; idx++ {
+		codes *= 3
+	}
+	return codes
+}
+
+// encodeScore converts a score into a number below the countScoreCodes of its
+// length.
+func encodeScore(score string) int {
+	code := 0
+	for _, letter := range score {
+		code *= 3
+		if letter == 'h' {
+			code += 1
+		} else if letter == 'H' {
+			code += 2
+		}
+	}
+	return code
+}
+
+// openerCombination is a fixed sequence of guesses played regardless of the
+// feedback, rated by the number of distinct combined scores it produces.
+type openerCombination struct {
+	words      []string
+	signatures int
+	worst      int
+}
+
+// combinationSearch finds the fixed sequences of guesses with the most
+// distinct signatures. The candidate words are sorted by their own number of
+// distinct scores, which bounds the signatures of every sequence they are
+// part of.
+type combinationSearch struct {
+	words      []string
+	codes      [][]uint16
+	distinct   []int
+	solutions  int
+	scoreCodes int
+	top        int
+	mutex      sync.Mutex
+	best       []openerCombination
+	marks      sync.Pool
+}
+
+func newCombinationSearch(guesses, solutions *[]string, pool, top int) *combinationSearch {
+	type candidate struct {
+		word     string
+		codes    []uint16
+		distinct int
+	}
+	scoreCodes := 0
+	if len(*solutions) > 0 {
+		scoreCodes = countScoreCodes(len((*solutions)[0]))
+	}
+	candidates := make([]candidate, len(*guesses))
+	parallelFor(len(*guesses), func(idx int) {
+		codes := make([]uint16, len(*solutions))
+		seen := make([]bool, scoreCodes)
+		distinct := 0
+		for i, solution := range *solutions {
+			code := encodeScore(scoreAgainst((*guesses)[idx], solution))
+			codes[i] = uint16(code)
+			if !seen[code] {
+				seen[code] = true
+				distinct += 1
+			}
+		}
+		candidates[idx] = candidate{(*guesses)[idx], codes, distinct}
+	})
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].distinct != candidates[j].distinct {
+			return candidates[i].distinct > candidates[j].distinct
+		}
+		return candidates[i].word < candidates[j].word
+	})
+	if pool > 0 && pool < len(candidates) {
+		candidates = candidates[:pool]
+	}
+
+	s := &combinationSearch{solutions: len(*solutions), scoreCodes: scoreCodes, top: top}
+	for _, c := range candidates {
+		s.words = append(s.words, c.word)
+		s.codes = append(s.codes, c.codes)
+		s.distinct = append(s.distinct, c.distinct)
+	}
+	return s
+}
+
+// threshold returns the number of signatures a sequence needs to be among the
+// best ones. Sequences with as many signatures as the last of the best ones
+// are still offered, so ties are decided by the worst bucket and the words
+// rather than by the order of the search.
+func (s *combinationSearch) threshold() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if len(s.best) < s.top {
+		return 0
+	}
+	return s.best[len(s.best)-1].signatures
+}
+
+func (s *combinationSearch) offer(combination openerCombination) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.best = append(s.best, combination)
+	sort.SliceStable(s.best, func(i, j int) bool {
+		if s.best[i].signatures != s.best[j].signatures {
+			return s.best[i].signatures > s.best[j].signatures
+		}
+		if s.best[i].worst != s.best[j].worst {
+			return s.best[i].worst < s.best[j].worst
+		}
+		return strings.Join(s.best[i].words, " ") < strings.Join(s.best[j].words, " ")
+	})
+	if len(s.best) > s.top {
+		s.best = s.best[:s.top]
+	}
+}
+
+// upperBound returns the most signatures a sequence can have, if one more
+// word with the given number of distinct scores is added to a sequence with
+// the given bucket sizes.
+func upperBound(bucketSizes []int, distinct int) int {
+	bound := 0
+	for _, size := range bucketSizes {
+		if size < distinct {
+			bound += size
+		} else {
+			bound += distinct
+		}
+	}
+	return bound
+}
+
+// buckets assigns every solution the number of its bucket for the given
+// sequence of candidate indices, and returns the sizes of all buckets.
+func (s *combinationSearch) buckets(indices []int) ([]int, []int) {
+	ids := make([]int, s.solutions)
+	for _, idx := range indices {
+		keys := map[int]int{}
+		for solution, code := range s.codes[idx] {
+			key := ids[solution]*s.scoreCodes + int(code)
+			if _, ok := keys[key]; !ok {
+				keys[key] = len(keys)
+			}
+			ids[solution] = keys[key]
+		}
+	}
+	sizes := make([]int, 0, s.solutions)
+	for _, id := range ids {
+		for len(sizes) <= id {
+			sizes = append(sizes, 0)
+		}
+		sizes[id] += 1
+	}
+	return ids, sizes
+}
+
+// markSet marks keys as seen, with a new stamp for every count, so the
+// marks don't have to be cleared in between.
+type markSet struct {
+	marks []int32
+	stamp int32
+}
+
+func (s *combinationSearch) getMarks() *markSet {
+	if m, ok := s.marks.Get().(*markSet); ok {
+		return m
+	}
+	return &markSet{marks: make([]int32, s.solutions*s.scoreCodes)}
+}
+
+// extend counts the signatures of a sequence, given by the bucket ids of its
+// first words, extended by the candidate with the given index.
+func (s *combinationSearch) extend(ids []int, idx int, m *markSet) int {
+	m.stamp += 1
+	signatures := 0
+	for solution, code := range s.codes[idx] {
+		key := ids[solution]*s.scoreCodes + int(code)
+		if m.marks[key] != m.stamp {
+			m.marks[key] = m.stamp
+			signatures += 1
+		}
+	}
+	return signatures
+}
+
+func (s *combinationSearch) combination(indices []int) openerCombination {
+	_, sizes := s.buckets(indices)
+	combination := openerCombination{signatures: len(sizes)}
+	for _, idx := range indices {
+		combination.words = append(combination.words, s.words[idx])
+	}
+	for _, size := range sizes {
+		if size > combination.worst {
+			combination.worst = size
+		}
+	}
+	return combination
+}
+
+// searchExtensions tries all candidates from the given index on as next word
+// of the sequence of candidate indices, skipping candidates that can't reach
+// the current threshold.
+func (s *combinationSearch) searchExtensions(prefix []int, first int, found func(indices []int)) {
+	m := s.getMarks()
+	defer s.marks.Put(m)
+	ids, sizes := s.buckets(prefix)
+	sort.Sort(sort.Reverse(sort.IntSlice(sizes)))
+	for idx := first; idx < len(s.words); idx++ {
+		if upperBound(sizes, s.distinct[idx]) < s.threshold() {
+			// all later candidates have at most as many distinct scores
+			return
+		}
+		if containsIndex(prefix, idx) {
+			continue
+		}
+		if s.extend(ids, idx, m) >= s.threshold() {
+			found(append(append([]int{}, prefix...), idx))
+		}
+	}
+}
+
+func containsIndex(indices []int, idx int) bool {
+	for _, i := range indices {
+		if i == idx {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *combinationSearch) searchPairs() {
+	parallelFor(len(s.words), func(first int) {
+		s.searchExtensions([]int{first}, first+1, func(pair []int) {
+			s.offer(s.combination(pair))
+		})
+	})
+}
+
+// searchTriples extends the given number of best pairs by a third word. It's
+// a heuristic, the best triple may start with a pair that isn't among them.
+func (s *combinationSearch) searchTriples(pairs int) {
+	s.top, pairs = pairs, s.top
+	s.searchPairs()
+	index := map[string]int{}
+	for idx, word := range s.words {
+		index[word] = idx
+	}
+	prefixes := [][]int{}
+	for _, pair := range s.best {
+		prefixes = append(prefixes, []int{index[pair.words[0]], index[pair.words[1]]})
+	}
+	s.top, s.best = pairs, nil
+
+	seen := sync.Map{}
+	parallelFor(len(prefixes), func(p int) {
+		s.searchExtensions(prefixes[p], 0, func(triple []int) {
+			key := append([]int{}, triple...)
+			sort.Ints(key)
+			if _, duplicate := seen.LoadOrStore(fmt.Sprint(key), true); !duplicate {
+				s.offer(s.combination(triple))
+			}
+		})
+	})
+}
+
+func fixedOpeners(args []string) error {
+	flags := flag.NewFlagSet("fixed-openers", flag.ExitOnError)
+	size := flags.Int("words", 2, "number of fixed openers, 2 or 3")
+	pool := flags.Int("pool", 1000, "only combine the words with the most distinct scores, 0 for all")
+	pairs := flags.Int("pairs", 100, "number of best pairs to extend to triples, triples of other pairs are not searched")
+	top := flags.Int("top", 10, "number of combinations to show")
+	flags.Parse(args)
+	if *size != 2 && *size != 3 {
+		return fmt.Errorf("Can't search for %v fixed openers, only 2 or 3", *size)
+	}
+	if dictionaryWordLength > maxEncodedLength {
+		return fmt.Errorf("Can't search fixed openers of %v letters, at most %v", dictionaryWordLength, maxEncodedLength)
+	}
+
+	solutions := applyToWordSlice(strings.ToUpper, &possibleSolutions)
+	fmt.Fprintf(messages, "Score all words ...\n")
+	s := newCombinationSearch(defaultGuesses(), solutions, *pool, *top)
+	fmt.Fprintf(messages, "Search best combinations of %v words ...\n", *size)
+	if *size == 2 {
+		s.searchPairs()
+	} else {
+		s.searchTriples(*pairs)
+	}
+
+	if machineOutput() {
+		r := newReport("fixed_openers", "rank", "openers", "signatures", "solutions", "worst")
+		for idx, c := range s.best {
+			r.add(idx+1, c.words, c.signatures, s.solutions, c.worst)
+		}
+		return r.emit()
+	}
+	w := tabwriter.NewWriter(messages, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Rank\tOpeners\tSignatures\tWorst")
+	for idx, c := range s.best {
+		fmt.Fprintf(w, "%v\t%v\t%v/%v\t%v\n", idx+1, strings.Join(c.words, " "), c.signatures, s.solutions, c.worst)
+	}
+	return w.Flush()
+}
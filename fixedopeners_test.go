@@ -0,0 +1,57 @@
+package main
+
+import "testing"
+
+func TestEncodeScore(t *testing.T) {
+	t.Run("with all kinds of letters", func(t *testing.T) {
+		if got := encodeScore(".hH"); got != 5 {
+			t.Errorf("Expected '5' got '%v'", got)
+		}
+	})
+
+	t.Run("with the highest score", func(t *testing.T) {
+		if got := encodeScore("HHHHH"); got != countScoreCodes(5)-1 {
+			t.Errorf("Expected '%v' got '%v'", countScoreCodes(5)-1, got)
+		}
+	})
+}
+
+func TestUpperBound(t *testing.T) {
+	t.Run("with buckets larger and smaller than the distinct scores", func(t *testing.T) {
+		if got := upperBound([]int{5, 3, 1}, 2); got != 5 {
+			t.Errorf("Expected '5' got '%v'", got)
+		}
+	})
+}
+
+func TestCombinationSearch(t *testing.T) {
+	guesses := []string{"ABC", "DEF", "AXY", "XYZ"}
+	solutions := []string{"ABD", "ABE", "ACD", "ACE", "XYZ"}
+
+	t.Run("best pair", func(t *testing.T) {
+		s := newCombinationSearch(&guesses, &solutions, 0, 1)
+		s.searchPairs()
+		compareWordSlices(t, &s.best[0].words, &[]string{"ABC", "DEF"})
+		if s.best[0].signatures != 5 || s.best[0].worst != 1 {
+			t.Errorf("Expected 5 signatures with worst 1, got %v with worst %v", s.best[0].signatures, s.best[0].worst)
+		}
+	})
+
+	t.Run("best triples", func(t *testing.T) {
+		s := newCombinationSearch(&guesses, &solutions, 0, 2)
+		s.searchTriples(2)
+		if len(s.best) != 2 {
+			t.Fatalf("Expected 2 triples got %v", len(s.best))
+		}
+		for _, c := range s.best {
+			if len(c.words) != 3 || c.signatures != 5 {
+				t.Errorf("Expected a triple with 5 signatures, got %v", c)
+			}
+		}
+	})
+
+	t.Run("with limited pool", func(t *testing.T) {
+		s := newCombinationSearch(&guesses, &solutions, 2, 1)
+		compareWordSlices(t, &s.words, &[]string{"ABC", "DEF"})
+	})
+}
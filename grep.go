@@ -0,0 +1,117 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+func searchWords(words *[]string, c *constraints, length int) *[]string {
+	return c.filter(cleanupWords(words, length))
+}
+
+func letterFrequencies(words *[]string) map[rune]int {
+	frequencies := map[rune]int{}
+	for _, word := range *words {
+		seen := map[rune]bool{}
+		for _, letter := range word {
+			if !seen[letter] {
+				frequencies[letter] += 1
+				seen[letter] = true
+			}
+		}
+	}
+	return frequencies
+}
+
+// sortByLetterFrequency sorts the words by the summed frequencies of their
+// distinct letters within the words themselves, most common first.
+func sortByLetterFrequency(words *[]string) *[]string {
+	return sortByLetterFrequencyAmong(words, words)
+}
+
+// sortByLetterFrequencyAmong sorts the words by the summed frequencies of
+// their distinct letters among the other words, most common first. The
+// guesses whose letters are common among the remaining words likely split
+// them best.
+func sortByLetterFrequencyAmong(words, others *[]string) *[]string {
+	frequencies := letterFrequencies(others)
+	wordScores := map[string]int{}
+	for _, word := range *words {
+		seen := map[rune]bool{}
+		for _, letter := range word {
+			if !seen[letter] {
+				wordScores[word] -= frequencies[letter]
+				seen[letter] = true
+			}
+		}
+	}
+	return getKeysSortedByValue(&wordScores)
+}
+
+// sortByRank sorts the words by how well they split each other, as the
+// solver would rank them if they were the only remaining words.
+func sortByRank(words *[]string) *[]string {
+	wg := &WordGame{allWords: words, remainingWords: words}
+	return wg.getBestGuesses()
+}
+
+func sortWords(words *[]string, order string) (*[]string, error) {
+	switch order {
+	case "alpha":
+		sorted := append([]string{}, *words...)
+		sort.Strings(sorted)
+		return &sorted, nil
+	case "frequency":
+		return sortByLetterFrequency(words), nil
+	case "rank":
+		return sortByRank(words), nil
+	}
+	return nil, fmt.Errorf("Unknown sort order '%v'", order)
+}
+
+func grep(args []string) error {
+	flags := flag.NewFlagSet("grep", flag.ExitOnError)
+	list := flags.String("list", "all", "word list to search: solutions, guesses or all")
+	order := flags.String("sort", "alpha", "sort order: alpha, frequency or rank")
+	length := flags.Int("length", 5, "word length, if no pattern is given")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: WordleSolver grep [flags] PATTERN [+LETTERS] [-LETTERS] [E=2] [, constraint ...]")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	c, err := parseConstraints(strings.Join(flags.Args(), " "))
+	if err != nil {
+		return err
+	}
+	if len(c.patterns) > 0 {
+		*length = len(c.patterns[0])
+	}
+
+	lists := map[string]*[]string{"solutions": &possibleSolutions, "guesses": &allWords}
+	names := []string{*list}
+	if *list == "all" {
+		names = []string{"solutions", "guesses"}
+	}
+
+	r := newReport("matches", "list", "word")
+	for _, name := range names {
+		words, ok := lists[name]
+		if !ok {
+			return fmt.Errorf("Unknown word list '%v'", name)
+		}
+		matches, err := sortWords(searchWords(words, c, *length), *order)
+		if err != nil {
+			return err
+		}
+		for _, word := range *matches {
+			r.add(name, word)
+		}
+		if !machineOutput() {
+			fmt.Fprintf(messages, "Matching %v (%v): %v\n", name, len(*matches), strings.Join(*matches, " "))
+		}
+	}
+	return r.emit()
+}
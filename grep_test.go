@@ -0,0 +1,52 @@
+package main
+
+import "testing"
+
+func TestSearchWords(t *testing.T) {
+	t.Run("with pattern and letter count", func(t *testing.T) {
+		words := []string{"geese", "eerie", "ge-se", "agree", "gees"}
+		c, _ := parseConstraints("..e.e E>=3")
+		reference := []string{"GEESE"}
+		compareWordSlices(t, searchWords(&words, c, 5), &reference)
+	})
+
+	t.Run("with exact letter count", func(t *testing.T) {
+		words := []string{"GEESE", "EERIE", "AGREE"}
+		c, _ := parseConstraints("E=2")
+		reference := []string{"AGREE"}
+		compareWordSlices(t, searchWords(&words, c, 5), &reference)
+	})
+}
+
+func TestSortWords(t *testing.T) {
+	words := []string{"ABC", "XYZ", "ABD", "ABE"}
+
+	t.Run("alphabetically", func(t *testing.T) {
+		got, _ := sortWords(&words, "alpha")
+		reference := []string{"ABC", "ABD", "ABE", "XYZ"}
+		compareWordSlices(t, got, &reference)
+	})
+
+	t.Run("by letter frequency", func(t *testing.T) {
+		got, _ := sortWords(&words, "frequency")
+		reference := []string{"ABC", "ABD", "ABE", "XYZ"}
+		compareWordSlices(t, got, &reference)
+	})
+
+	t.Run("by letter frequency among other words", func(t *testing.T) {
+		remaining := []string{"ABC", "ABD", "AEF"}
+		guesses := []string{"XYZ", "ABD", "FGH", "ABC"}
+		compareWordSlices(t, &[]string{"ABC", "ABD", "FGH", "XYZ"}, sortByLetterFrequencyAmong(&guesses, &remaining))
+	})
+
+	t.Run("by rank", func(t *testing.T) {
+		got, _ := sortWords(&words, "rank")
+		expectGotString(t, "XYZ", (*got)[3])
+	})
+
+	t.Run("with unknown order", func(t *testing.T) {
+		if _, err := sortWords(&words, "random"); err == nil {
+			t.Errorf("Expected an error for an unknown sort order")
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// letterHeatmap counts how often every letter occurs at every position of
+// the remaining words.
+type letterHeatmap struct {
+	total     int
+	positions []map[rune]int
+	letters   map[rune]int
+}
+
+func (wg *WordGame) heatmap() *letterHeatmap {
+	h := &letterHeatmap{total: len(*wg.remainingWords), letters: letterFrequencies(wg.remainingWords)}
+	for _, word := range *wg.remainingWords {
+		for idx, letter := range word {
+			for len(h.positions) <= idx {
+				h.positions = append(h.positions, map[rune]int{})
+			}
+			h.positions[idx][letter] += 1
+		}
+	}
+	return h
+}
+
+func (h *letterHeatmap) probability(position int, letter rune) float64 {
+	if h.total == 0 {
+		return 0
+	}
+	return float64(h.positions[position][letter]) / float64(h.total)
+}
+
+// informativeLetters returns the letters that are neither known to be in
+// every remaining word nor in none of them, sorted by how evenly they split
+// the remaining words. Letters of previous guesses are always known, so
+// these are the untested letters.
+func (h *letterHeatmap) informativeLetters() *[]string {
+	scores := map[string]int{}
+	for letter, count := range h.letters {
+		if count == h.total {
+			continue
+		}
+		distance := 2*count - h.total
+		if distance < 0 {
+			distance = -distance
+		}
+		scores[string(letter)] = distance
+	}
+	return getKeysSortedByValue(&scores)
+}
+
+func heatColor(p float64) string {
+	switch {
+	case p == 0:
+		return "\033[90m"
+	case p < 0.1:
+		return "\033[37m"
+	case p < 0.3:
+		return "\033[33m"
+	}
+	return "\033[32m"
+}
+
+// report lists the probability of every letter at every position, with
+// position 0 for anywhere in the word.
+func (h *letterHeatmap) report() *report {
+	letters := []rune{}
+	for letter := range h.letters {
+		letters = append(letters, letter)
+	}
+	sort.Slice(letters, func(i, j int) bool { return letters[i] < letters[j] })
+	r := newReport("heatmap", "letter", "position", "probability")
+	for _, letter := range letters {
+		for idx := range h.positions {
+			r.add(string(letter), idx+1, h.probability(idx, letter))
+		}
+		// letters are only listed if there are remaining words
+		r.add(string(letter), 0, float64(h.letters[letter])/float64(h.total))
+	}
+	return r
+}
+
+func (h *letterHeatmap) print(w io.Writer, color bool) {
+	letters := []rune{}
+	for letter := range h.letters {
+		letters = append(letters, letter)
+	}
+	sort.Slice(letters, func(i, j int) bool { return letters[i] < letters[j] })
+
+	fmt.Fprintf(w, "Remaining words: %v\n", h.total)
+	fmt.Fprint(w, "   ")
+	for idx := range h.positions {
+		fmt.Fprintf(w, "%5v", idx+1)
+	}
+	fmt.Fprintln(w, "  total")
+	for _, letter := range letters {
+		fmt.Fprintf(w, "%v  ", string(letter))
+		for idx := range h.positions {
+			p := h.probability(idx, letter)
+			cell := fmt.Sprintf("%5.0f", 100*p)
+			if p == 0 {
+				cell = "    ."
+			}
+			if color {
+				cell = heatColor(p) + cell + "\033[0m"
+			}
+			fmt.Fprint(w, cell)
+		}
+		fmt.Fprintf(w, "  %5.0f\n", 100*float64(h.letters[letter])/float64(h.total))
+	}
+	fmt.Fprintf(w, "Most informative letters: %v\n", strings.Join(*h.informativeLetters(), " "))
+}
+
+func heatmap(args []string) error {
+	flags := flag.NewFlagSet("heatmap", flag.ExitOnError)
+	history := flags.String("history", "", "previous turns, e.g. 'AESIR:.h.h.,CLINE:h.hhH'")
+	noColor := flags.Bool("no-color", false, "disable terminal colors, which are only used on a terminal")
+	flags.Parse(args)
+
+	wg := createDefaultWordGame()
+	guesses, scores, err := parseHistory(*history, wg.wordLength())
+	if err != nil {
+		return err
+	}
+	for idx, guess := range *guesses {
+		wg.guess(guess, (*scores)[idx])
+	}
+	if machineOutput() {
+		return wg.heatmap().report().emit()
+	}
+	wg.heatmap().print(messages, !*noColor && isTerminal(messages))
+	return nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWordGameHeatmap(t *testing.T) {
+	words := []string{"ABC", "ABD", "AXE", "ZBC"}
+	wordGame := createWordGame(&words, 3)
+	h := wordGame.heatmap()
+
+	t.Run("letter probabilities by position", func(t *testing.T) {
+		expectGotFloat(t, 0.75, h.probability(0, 'A'))
+		expectGotFloat(t, 0.25, h.probability(0, 'Z'))
+		expectGotFloat(t, 0.5, h.probability(2, 'C'))
+		expectGotFloat(t, 0, h.probability(2, 'A'))
+	})
+
+	t.Run("most informative letters", func(t *testing.T) {
+		reference := []string{"C", "A", "B", "D", "E", "X", "Z"}
+		compareWordSlices(t, h.informativeLetters(), &reference)
+	})
+
+	t.Run("printed without colors", func(t *testing.T) {
+		var buffer bytes.Buffer
+		h.print(&buffer, false)
+		if strings.Contains(buffer.String(), "\033[") {
+			t.Errorf("Expected no color codes in '%v'", buffer.String())
+		}
+		if !strings.Contains(buffer.String(), "Remaining words: 4") {
+			t.Errorf("Expected the number of remaining words in '%v'", buffer.String())
+		}
+	})
+}
@@ -1,11 +1,19 @@
 package main
 
 import (
+	"bufio"
+	"context"
+	"flag"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"sort"
 	"strings"
+	"sync/atomic"
+	"time"
 	"unicode"
+	"unicode/utf8"
 )
 
 type wordFunc func(string) string
@@ -13,6 +21,16 @@ type wordFunc func(string) string
 type WordGame struct {
 	allWords       *[]string
 	remainingWords *[]string
+	strategy       string
+	lies           int
+	progress       progressFunc
+	budget         time.Duration
+	pool           guessPool
+	// scorer scores the guesses, Wordle tiles if nil
+	scorer Scorer
+	// distribution counts the scores of a guess over the candidates of the
+	// game, the remaining words if nil
+	distribution func(guess string) map[string]int
 }
 
 func readDictionary(path string) *[]string {
@@ -36,9 +54,25 @@ func applyToWordSlice(f wordFunc, words *[]string) *[]string {
 	return &newWords
 }
 
+func containsWord(words *[]string, word string) bool {
+	for _, w := range *words {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
+
+// isWordLetter reports whether the letter can be part of a word. Words only
+// have the ASCII letters A to Z, so every letter is a single byte and the
+// length of a word is its number of letters.
+func isWordLetter(letter rune) bool {
+	return letter < utf8.RuneSelf && unicode.IsLetter(letter)
+}
+
 func hasNoSpecialCharacters(word string) string {
 	for _, letter := range word {
-		if !unicode.IsLetter(letter) {
+		if !isWordLetter(letter) {
 			return ""
 		}
 	}
@@ -65,30 +99,94 @@ func createWordGameFromDictionary(path string, length int) *WordGame {
 }
 
 func createWordGame(words *[]string, length int) *WordGame {
-	words = cleanupWords(words, length)
+	words, _ = canonicalWords(cleanupWords(words, length))
 	remainingWords := make([]string, len(*words))
 	copy(remainingWords, *words)
-	return &WordGame{words, &remainingWords}
+	return &WordGame{allWords: words, remainingWords: &remainingWords}
 }
 
 func createWordGameFromWordLists(allWords *[]string, remainingWords *[]string) *WordGame {
-	allWords = applyToWordSlice(strings.ToUpper, allWords)
-	remainingWords = applyToWordSlice(strings.ToUpper, remainingWords)
-	return &WordGame{allWords, remainingWords}
+	allWords, remainingWords, _ = canonicalWordLists(allWords, remainingWords, false)
+	return &WordGame{allWords: allWords, remainingWords: remainingWords}
+}
+
+// createDefaultWordGame returns a game with the guesses and solutions of the
+// current dictionary. Like play without -add-solutions, the solutions aren't
+// rated as guesses.
+func createDefaultWordGame() *WordGame {
+	return createWordGameFromWordLists(&allWords, &possibleSolutions)
 }
 
+// defaultGuesses returns the canonical guesses of the current dictionary
+// together with its solutions, as every solution is a valid guess too. The
+// tools searching openers and the code breaking games always rate them, play
+// only with -add-solutions.
+func defaultGuesses() *[]string {
+	words := append(append([]string{}, allWords...), possibleSolutions...)
+	guesses, _ := canonicalWords(&words)
+	return guesses
+}
+
+// wordListStatistics describes the word lists of a game after loading them.
+type wordListStatistics struct {
+	guesses            int
+	solutions          int
+	duplicateGuesses   int
+	duplicateSolutions int
+	missingSolutions   int
+	addedSolutions     bool
+}
+
+// canonicalWords returns the uppercased words sorted and without duplicates,
+// and the number of duplicates removed.
+func canonicalWords(words *[]string) (*[]string, int) {
+	canonical := mergeWords(applyToWordSlice(strings.ToUpper, words))
+	return canonical, len(*words) - len(*canonical)
+}
+
+// canonicalWordLists returns the canonical guesses and solutions. Solutions
+// that aren't valid guesses are added to the guesses if requested.
+func canonicalWordLists(allWords, solutions *[]string, addMissing bool) (*[]string, *[]string, wordListStatistics) {
+	stats := wordListStatistics{addedSolutions: addMissing}
+	allWords, stats.duplicateGuesses = canonicalWords(allWords)
+	solutions, stats.duplicateSolutions = canonicalWords(solutions)
+	missing := missingWords(solutions, allWords)
+	stats.missingSolutions = len(*missing)
+	if addMissing && len(*missing) > 0 {
+		allWords = mergeWords(allWords, missing)
+	}
+	stats.guesses, stats.solutions = len(*allWords), len(*solutions)
+	return allWords, solutions, stats
+}
+
+func (s wordListStatistics) print(w io.Writer) {
+	fmt.Fprintf(w, "Word lists: %v guesses, %v solutions, %v duplicates removed\n",
+		s.guesses, s.solutions, s.duplicateGuesses+s.duplicateSolutions)
+	if s.missingSolutions == 0 {
+		return
+	}
+	if s.addedSolutions {
+		fmt.Fprintf(w, "Added %v solutions to the guesses\n", s.missingSolutions)
+	} else {
+		fmt.Fprintf(w, "Warning: %v solutions are not in the guesses, add them with -add-solutions\n", s.missingSolutions)
+	}
+}
+
+// scoreAgainst scores every letter of the guess with H if it's at the same
+// position in the solution, h if it's elsewhere in the solution and . if
+// it's not in the solution. Words are ASCII, so letters are compared as bytes.
 func scoreAgainst(guess, solution string) string {
 	if len(guess) != len(solution) {
 		panic(fmt.Errorf("Can't score guess '%v' against '%v' with different length", guess, solution))
 	}
-	score := []rune{}
-	for idx, letter := range guess {
-		if letter == rune(solution[idx]) {
-			score = append(score, 'H')
-		} else if strings.ContainsRune(solution, letter) {
-			score = append(score, 'h')
+	score := make([]byte, len(guess))
+	for idx := 0; idx < len(guess); idx++ {
+		if guess[idx] == solution[idx] {
+			score[idx] = 'H'
+		} else if strings.IndexByte(solution, guess[idx]) >= 0 {
+			score[idx] = 'h'
 		} else {
-			score = append(score, '.')
+			score[idx] = '.'
 		}
 	}
 	return string(score)
@@ -115,29 +213,122 @@ func getKeysSortedByValue(toSort *map[string]int) *[]string {
 	return &result
 }
 
-func (wg *WordGame) getBestGuesses() *[]string {
-	wordScores := map[string]int{}
-	for _, word := range *wg.allWords {
-		scores := map[string]int{}
-		for _, solution := range *wg.remainingWords {
-			score := scoreAgainst(word, solution)
+// wordLength returns the length of the words of the game.
+func (wg *WordGame) wordLength() int {
+	if len(*wg.allWords) == 0 {
+		return 0
+	}
+	return len((*wg.allWords)[0])
+}
+
+// score scores the guess against the solution with the scorer of the game.
+func (wg *WordGame) score(guess, solution string) string {
+	if wg.scorer == nil {
+		return scoreAgainst(guess, solution)
+	}
+	return wg.scorer.Score(guess, solution)
+}
+
+func (wg *WordGame) scoreDistribution(guess string) map[string]int {
+	scores := map[string]int{}
+	for _, solution := range *wg.remainingWords {
+		score := wg.score(guess, solution)
+		if wg.lies == 0 {
 			scores[score] += 1
+			continue
 		}
-		maxCount := 0
-		for _, count := range scores {
-			if count > maxCount {
-				maxCount = count
-			}
+		for _, reported := range *lyingScores(score, wg.lies) {
+			scores[reported] += 1
 		}
-		wordScores[word] = maxCount
 	}
-	return getKeysSortedByValue(&wordScores)
+	return scores
+}
+
+// candidateDistribution counts the scores of the guess over the candidates of
+// the game.
+func (wg *WordGame) candidateDistribution(guess string) map[string]int {
+	if wg.distribution == nil {
+		return wg.scoreDistribution(guess)
+	}
+	return wg.distribution(guess)
+}
+
+func (wg *WordGame) getGuessWeights() map[string]float64 {
+	return wg.getGuessWeightsContext(context.Background())
+}
+
+// getGuessWeightsContext rates the guesses of the pool, and in guarantee mode
+// the pruned guesses that might be better. Once the context is done or the
+// time budget is used up the remaining guesses are skipped.
+func (wg *WordGame) getGuessWeightsContext(ctx context.Context) map[string]float64 {
+	if wg.budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, wg.budget)
+		defer cancel()
+	}
+	guesses, pruned := wg.guessPool()
+	wordScores := wg.rateGuesses(ctx, guesses)
+	if wg.pool.guarantee && len(*pruned) > 0 {
+		best := math.Inf(1)
+		for _, weight := range wordScores {
+			best = math.Min(best, weight)
+		}
+		unproven := wg.unprovenGuesses(pruned, best)
+		for word, weight := range wg.rateGuesses(ctx, unproven) {
+			wordScores[word] = weight
+		}
+	}
+	return wordScores
+}
+
+// rateGuesses rates the guesses until the context is done. With a time budget
+// the guesses with the most common letters among the remaining words are
+// rated first. Only the rated guesses are returned, at least the first one.
+func (wg *WordGame) rateGuesses(ctx context.Context, guesses *[]string) map[string]float64 {
+	weight := strategies[wg.strategyName()]
+	if wg.budget > 0 {
+		guesses = sortByLetterFrequencyAmong(guesses, wg.remainingWords)
+	}
+	weights := make([]float64, len(*guesses))
+	rated := make([]bool, len(*guesses))
+	evaluated := int64(0)
+	parallelFor(len(weights), func(idx int) {
+		if idx > 0 && ctx.Err() != nil {
+			return
+		}
+		weights[idx] = weight(wg.candidateDistribution((*guesses)[idx]))
+		rated[idx] = true
+		if wg.progress != nil {
+			wg.progress(int(atomic.AddInt64(&evaluated, 1)), len(weights))
+		}
+	})
+	if wg.progress != nil && int(evaluated) < len(weights) {
+		// the budget is used up or the context is done, the remaining
+		// guesses are skipped
+		wg.progress(len(weights), len(weights))
+	}
+	wordScores := map[string]float64{}
+	for idx, word := range *guesses {
+		if rated[idx] {
+			wordScores[word] = weights[idx]
+		}
+	}
+	return wordScores
+}
+
+func (wg *WordGame) getBestGuesses() *[]string {
+	return wg.getBestGuessesContext(context.Background())
+}
+
+func (wg *WordGame) getBestGuessesContext(ctx context.Context) *[]string {
+	wordScores := wg.getGuessWeightsContext(ctx)
+	return getKeysSortedByWeight(&wordScores)
 }
 
 func (wg *WordGame) guess(guess, score string) {
 	newRemainingWords := []string{}
 	for _, word := range *wg.remainingWords {
-		if scoreAgainst(guess, word) == score {
+		if scoreMatches(wg.score(guess, word), score, wg.lies) {
 			newRemainingWords = append(newRemainingWords, word)
 		}
 	}
@@ -151,6 +342,8 @@ func toUniqueScore(score string) string {
 			uniqueScore = append(uniqueScore, 'h')
 		} else if unicode.IsUpper(letter) {
 			uniqueScore = append(uniqueScore, 'H')
+		} else if letter == unknownTile {
+			uniqueScore = append(uniqueScore, unknownTile)
 		} else {
 			uniqueScore = append(uniqueScore, '.')
 		}
@@ -158,44 +351,269 @@ func toUniqueScore(score string) string {
 	return string(uniqueScore)
 }
 
-func main() {
-	length := 5
-	wg := createWordGameFromWordLists(&allWords, &possibleSolutions)
+var stdin = bufio.NewReader(os.Stdin)
+
+func readLine(prompt string) string {
+	fmt.Fprint(messages, prompt)
+	line, err := stdin.ReadString('\n')
+	if err != nil && line == "" {
+		fmt.Fprintln(messages)
+		os.Exit(0)
+	}
+	return strings.TrimSpace(line)
+}
+
+func applyConstraintInput(wg *WordGame, input string) bool {
+	c, err := parseConstraints(input)
+	if err != nil {
+		fmt.Fprintf(messages, "Invalid constraints '%v': %v\n", input, err)
+		return false
+	}
+	wg.constrain(c)
+	fmt.Fprintf(messages, "Remaining words: %v\n", len(*wg.remainingWords))
+	return true
+}
+
+func play(args []string) error {
+	flags := flag.NewFlagSet("play", flag.ExitOnError)
+	constraintInput := flags.String("constraints", "", "known constraints, e.g. 'A at 1, E present not at 5, no R S T'")
+	suggestions := flags.Int("suggestions", 12, "number of best guesses to show")
+	save := flags.String("save", "", "save the game to `FILE` after every turn")
+	resume := flags.String("resume", "", "resume the game saved in `FILE` and keep saving it there")
+	answerHistory := flags.String("answers", "", "exclude the past answers listed in `FILE` from the solutions")
+	strategy := flags.String("strategy", defaultStrategy, "ranking strategy: "+strings.Join(strategyNames(), ", "))
+	lies := flags.Int("lies", 0, "number of wrong tiles in every score, 1 for Fibble")
+	budget := flags.Duration("budget", 0, "rank only as many guesses as possible within this time, e.g. 2s")
+	poolInput := flags.String("pool", "all", "guesses to rate: "+strings.Join(guessPoolModes, ", "))
+	guarantee := flags.Bool("guarantee", false, "also rate the pruned guesses that might be better than the pool")
+	addSolutions := flags.Bool("add-solutions", false, "add the solutions missing from the guesses to the guesses")
+	flags.Parse(args)
+
+	if err := checkStrategy(*strategy); err != nil {
+		return err
+	}
+	pool, err := parseGuessPool(*poolInput, *guarantee)
+	if err != nil {
+		return err
+	}
+	length := dictionaryWordLength
+	if *lies < 0 || *lies > length {
+		return fmt.Errorf("Can't play with %v lies in a score of length %v", *lies, length)
+	}
+	dictionary := identifyDictionary(currentDictionary, &allWords, &possibleSolutions)
+	settings := sessionSettings{Suggestions: *suggestions, Strategy: *strategy, Lies: *lies, AddSolutions: *addSolutions}
+	if *answerHistory != "" {
+		history, err := readAnswerHistory(*answerHistory)
+		if err != nil {
+			return err
+		}
+		settings.ExcludedAnswers = *pastAnswerWords(history)
+	}
+	sess := newSession(dictionary, settings)
+	path := *save
+	if *resume != "" {
+		var err error
+		if sess, err = loadSession(*resume); err != nil {
+			return err
+		}
+		if err := sess.applyFlags(flags); err != nil {
+			return err
+		}
+		path = *resume
+	}
+	solutions := excludeWords(&possibleSolutions, &sess.Settings.ExcludedAnswers)
+	if len(sess.Settings.ExcludedAnswers) > 0 {
+		fmt.Fprintf(messages, "Excluded %v past answers\n", len(possibleSolutions)-len(*solutions))
+	}
+	guesses, solutions, stats := canonicalWordLists(&allWords, solutions, sess.Settings.AddSolutions)
+	stats.print(messages)
+	wg := &WordGame{allWords: guesses, remainingWords: solutions}
+	wg.strategy = sess.Settings.Strategy
+	wg.lies = sess.Settings.Lies
+	if *resume != "" {
+		if err := sess.replay(wg, dictionary); err != nil {
+			return err
+		}
+		fmt.Fprintf(messages, "Resumed game after %v turns\n", len(sess.Turns))
+	}
+	autoSave := func() {
+		if path == "" {
+			return
+		}
+		if err := sess.save(path); err != nil {
+			fmt.Fprintf(messages, "Can't save game to '%v': %v\n", path, err)
+		}
+	}
+
+	if *constraintInput != "" && applyConstraintInput(wg, *constraintInput) {
+		sess.recordConstraints(*constraintInput)
+		autoSave()
+	}
+	wg.budget = *budget
+	wg.pool = pool
+	if isTerminal(messages) {
+		wg.progress = newProgressBar(messages).update
+	}
 	var guess string
 	var score string
-	fmt.Printf("Calculate best guesses ...\n")
+	fmt.Fprintf(messages, "Calculate best guesses ...\n")
 	for len(*wg.remainingWords) > 1 {
-		fmt.Printf("Best guesses: %v\n", (*wg.getBestGuesses())[:12])
+		weights := wg.getGuessWeights()
+		if wg.budget > 0 && len(weights) < len(*wg.allWords) {
+			fmt.Fprintf(messages, "Ranked %v of %v guesses within %v\n", len(weights), len(*wg.allWords), wg.budget)
+		}
+		bestGuesses := *getKeysSortedByWeight(&weights)
+		if len(bestGuesses) > sess.Settings.Suggestions {
+			bestGuesses = bestGuesses[:sess.Settings.Suggestions]
+		}
+		fmt.Fprintf(messages, "Best guesses: %v\n", bestGuesses)
+		if err := suggestionsReport(bestGuesses, weights).emit(); err != nil {
+			return err
+		}
 
 		guess = ""
 		for len(guess) != length {
-			fmt.Printf("Your guess: ")
-			fmt.Scanln(&guess)
+			guess = readLine("Your guess: ")
+			if strings.HasPrefix(guess, ":") {
+				break
+			}
+			if strings.HasPrefix(guess, "?") {
+				explanation, err := wg.explain(strings.ToUpper(guess[1:]))
+				if err != nil {
+					fmt.Fprintln(messages, err)
+					guess = ""
+					continue
+				}
+				explanation.print(messages, 5)
+				if err := explanation.report().emit(); err != nil {
+					return err
+				}
+				guess = ""
+				continue
+			}
 			if len(guess) != length {
-				fmt.Printf("Invalid length guess '%v'\n", guess)
+				fmt.Fprintf(messages, "Invalid length guess '%v'\n", guess)
 			}
 			guess = strings.ToUpper(guess)
 		}
+		if strings.HasPrefix(guess, ":") {
+			if applyConstraintInput(wg, guess[1:]) {
+				sess.recordConstraints(guess[1:])
+				autoSave()
+			}
+			continue
+		}
 
 		score = ""
 		for len(score) != length {
-			fmt.Printf("Score of the guess: ")
-			fmt.Scanln(&score)
+			score = readLine("Score of the guess: ")
 			if len(score) != length {
-				fmt.Printf("Invalid length score '%v'\n", score)
+				fmt.Fprintf(messages, "Invalid length score '%v'\n", score)
 			}
 			score = toUniqueScore(score)
 		}
 
-		if score == strings.Repeat("H", length) {
-			os.Exit(0)
+		if hasUnknownTiles(score) {
+			counts := wg.possibilities(guess, score)
+			printPossibilities(counts)
+			if err := possibilitiesReport(counts).emit(); err != nil {
+				return err
+			}
+		}
+		sess.recordGuess(guess, score)
+		autoSave()
+		// with lies an all-hit score may be a lie itself, the game goes on
+		// until a single word remains
+		if score == strings.Repeat("H", length) && wg.lies == 0 {
+			return nil
 		}
 		wg.guess(guess, score)
+		if err := remainingReport(wg.remainingWords).emit(); err != nil {
+			return err
+		}
 	}
 	if len(*wg.remainingWords) == 1 {
-		fmt.Printf("The solution is: %v\n", (*wg.remainingWords)[0])
+		fmt.Fprintf(messages, "The solution is: %v\n", (*wg.remainingWords)[0])
 	} else {
-		fmt.Println("No solution found :-(")
+		fmt.Fprintln(messages, "No solution found :-(")
+	}
+	if err := solutionReport(wg.remainingWords).emit(); err != nil {
+		return err
+	}
+	readLine("")
+	return nil
+}
+
+var commands = map[string]func(args []string) error{
+	"play":           play,
+	"grep":           grep,
+	"analyze":        analyze,
+	"explain":        explain,
+	"heatmap":        heatmap,
+	"tui":            runTUI,
+	"answers":        answers,
+	"tournament":     tournament,
+	"openers":        openers,
+	"fixed-openers":  fixedOpeners,
+	"xordle":         xordle,
+	"mastermind":     mastermind,
+	"bulls-and-cows": bullsAndCows,
+	"wordle":         wordle,
+	"nerdle":         nerdle,
+	"dict":           dict,
+	"rpc":            rpc,
+	"batch":          batch,
+}
+
+// globalOptions are options given before the command, like
+// "-dictionary NAME" or "--output=json". "-format" is an alias of "-output".
+var globalOptions = map[string]func(value string) error{
+	"dictionary": useDictionary,
+	"output":     useOutput,
+	"format":     useOutput,
+}
+
+// parseGlobalOptions applies the leading global options and returns the
+// remaining arguments.
+func parseGlobalOptions(args []string) ([]string, error) {
+	for len(args) > 0 && strings.HasPrefix(args[0], "-") {
+		option := strings.TrimLeft(args[0], "-")
+		value, hasValue := "", false
+		if idx := strings.Index(option, "="); idx >= 0 {
+			option, value, hasValue = option[:idx], option[idx+1:], true
+		}
+		apply, ok := globalOptions[option]
+		if !ok {
+			break
+		}
+		if !hasValue {
+			if len(args) < 2 {
+				return nil, fmt.Errorf("Missing value for option '%v'", args[0])
+			}
+			value, args = args[1], args[1:]
+		}
+		if err := apply(value); err != nil {
+			return nil, err
+		}
+		args = args[1:]
+	}
+	return args, nil
+}
+
+func main() {
+	args, err := parseGlobalOptions(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	command := play
+	if len(args) > 0 {
+		if c, ok := commands[args[0]]; ok {
+			command, args = c, args[1:]
+		}
+	}
+	if err := command(args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
-    fmt.Scanln()
 }
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"context"
+	"sort"
 	"strings"
 	"testing"
+	"time"
 )
 
 func compareWordSlices(t testing.TB, slice1, slice2 *[]string) {
@@ -78,6 +81,12 @@ func TestHasNoSpecialCharacters(t *testing.T) {
 		got := hasNoSpecialCharacters(string("AB*C"))
 		expectGotString(t, expect, got)
 	})
+
+	t.Run("with multibyte letters", func(t *testing.T) {
+		expect := string("")
+		got := hasNoSpecialCharacters(string("ÄPFEL"))
+		expectGotString(t, expect, got)
+	})
 }
 
 func TestCleanupWords(t *testing.T) {
@@ -142,6 +151,12 @@ func TestScoreAgainst(t *testing.T) {
 		score := scoreAgainst("aae", "bca")
 		expectGotString(t, "hh.", score)
 	})
+
+	t.Run("with repeating letters in guess and solution", func(t *testing.T) {
+		score := scoreAgainst("ABBEY", "BABES")
+		expectGotString(t, "hhHH.", score)
+	})
+
 }
 
 func TestGetKeysSortedByValue(t *testing.T) {
@@ -180,6 +195,15 @@ func TestWordGameGetBestGuesses(t *testing.T) {
 		worst := (*bestGuesses)[4]
 		expectGotString(t, "XXX", worst)
 	})
+	t.Run("with a cancelled context", func(t *testing.T) {
+		words := []string{"AXY", "BXY", "CXY", "ABC", "XXX"}
+		wordGame := createWordGame(&words, 3)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if bestGuesses := wordGame.getBestGuessesContext(ctx); len(*bestGuesses) != 1 {
+			t.Errorf("Expected only the first guess rated got %v", *bestGuesses)
+		}
+	})
 }
 
 func TestToUniqueScore(t *testing.T) {
@@ -212,4 +236,67 @@ func TestToUniqueScore(t *testing.T) {
 		expect := ".hH"
 		expectGotString(t, expect, got)
 	})
+
+	t.Run("with unknown tile", func(t *testing.T) {
+		got := toUniqueScore("?aB")
+		expect := "?hH"
+		expectGotString(t, expect, got)
+	})
+}
+
+func TestCanonicalWordLists(t *testing.T) {
+	guesses := []string{"slate", "CRANE", "Slate", "adieu"}
+	solutions := []string{"trace", "crane", "trace"}
+
+	t.Run("deduplicates and sorts", func(t *testing.T) {
+		allWords, remaining, stats := canonicalWordLists(&guesses, &solutions, false)
+		compareWordSlices(t, &[]string{"ADIEU", "CRANE", "SLATE"}, allWords)
+		compareWordSlices(t, &[]string{"CRANE", "TRACE"}, remaining)
+		reference := wordListStatistics{guesses: 3, solutions: 2, duplicateGuesses: 1, duplicateSolutions: 1, missingSolutions: 1}
+		if stats != reference {
+			t.Errorf("Expected %+v got %+v", reference, stats)
+		}
+	})
+
+	t.Run("adds missing solutions", func(t *testing.T) {
+		allWords, _, stats := canonicalWordLists(&guesses, &solutions, true)
+		compareWordSlices(t, &[]string{"ADIEU", "CRANE", "SLATE", "TRACE"}, allWords)
+		if stats.guesses != 4 || stats.missingSolutions != 1 || !stats.addedSolutions {
+			t.Errorf("Expected one added solution, got %+v", stats)
+		}
+	})
+}
+
+func TestDefaultGuesses(t *testing.T) {
+	guesses := defaultGuesses()
+	if len(*guesses) != len(allWords)+len(possibleSolutions) {
+		t.Errorf("Expected %v guesses got %v", len(allWords)+len(possibleSolutions), len(*guesses))
+	}
+	solutions := applyToWordSlice(strings.ToUpper, &possibleSolutions)
+	compareWordSlices(t, &[]string{}, missingWords(solutions, guesses))
+	if !sort.StringsAreSorted(*guesses) {
+		t.Errorf("Expected sorted guesses")
+	}
+}
+
+func TestBudget(t *testing.T) {
+	words := *allCodes("ABCDEFGH", 4, false)
+
+	t.Run("rates all guesses without budget", func(t *testing.T) {
+		wg := &WordGame{allWords: &words, remainingWords: &words}
+		if weights := wg.getGuessWeights(); len(weights) != len(words) {
+			t.Errorf("Expected %v rated guesses, got %v", len(words), len(weights))
+		}
+	})
+
+	t.Run("rates the best candidates within the budget", func(t *testing.T) {
+		wg := &WordGame{allWords: &words, remainingWords: &words, budget: time.Nanosecond}
+		weights := wg.getGuessWeights()
+		if len(weights) == 0 || len(weights) == len(words) {
+			t.Errorf("Expected some of %v guesses to be rated, got %v", len(words), len(weights))
+		}
+		if _, ok := weights[(*sortByLetterFrequency(&words))[0]]; !ok {
+			t.Errorf("Expected the first candidate to be rated")
+		}
+	})
 }
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// In Nerdle the hidden word is an equation like "12+35=47". The left side
+// is a calculation of positive numbers without leading zeros, evaluated with
+// the usual operator precedence, the right side is its non-negative integer
+// result. Guesses are scored like words.
+
+const equationOperators = "+-*/"
+
+func hasOnlyEquationSymbols(word string) string {
+	for _, symbol := range word {
+		if !strings.ContainsRune("0123456789="+equationOperators, symbol) {
+			return ""
+		}
+	}
+	return word
+}
+
+func isNumber(token string, allowZero bool) bool {
+	if token == "" || (token[0] == '0' && (len(token) > 1 || !allowZero)) {
+		return false
+	}
+	for _, digit := range token {
+		if digit < '0' || digit > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluateExpression returns the value of a calculation as a fraction.
+func evaluateExpression(expression string) (numerator, denominator int64, err error) {
+	numbers, operators := []int64{}, []byte{}
+	start := 0
+	for idx := 0; idx <= len(expression); idx++ {
+		if idx < len(expression) && strings.IndexByte(equationOperators, expression[idx]) < 0 {
+			continue
+		}
+		token := expression[start:idx]
+		if !isNumber(token, false) {
+			return 0, 0, fmt.Errorf("Invalid number '%v' in '%v'", token, expression)
+		}
+		number, err := strconv.ParseInt(token, 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+		numbers = append(numbers, number)
+		if idx < len(expression) {
+			operators = append(operators, expression[idx])
+		}
+		start = idx + 1
+	}
+
+	// add up the terms, multiplying and dividing within each term
+	sumNumerator, sumDenominator := int64(0), int64(1)
+	termNumerator, termDenominator, sign := numbers[0], int64(1), int64(1)
+	for idx, operator := range operators {
+		number := numbers[idx+1]
+		switch operator {
+		case '*':
+			termNumerator *= number
+		case '/':
+			termDenominator *= number
+		default:
+			sumNumerator = sumNumerator*termDenominator + sign*termNumerator*sumDenominator
+			sumDenominator *= termDenominator
+			termNumerator, termDenominator, sign = number, 1, 1
+			if operator == '-' {
+				sign = -1
+			}
+		}
+	}
+	sumNumerator = sumNumerator*termDenominator + sign*termNumerator*sumDenominator
+	sumDenominator *= termDenominator
+	return sumNumerator, sumDenominator, nil
+}
+
+// isValidEquation returns the equation if it is a valid Nerdle equation, an
+// empty string otherwise.
+func isValidEquation(word string) string {
+	sides := strings.Split(word, "=")
+	if len(sides) != 2 || !strings.ContainsAny(sides[0], equationOperators) || !isNumber(sides[1], true) {
+		return ""
+	}
+	numerator, denominator, err := evaluateExpression(sides[0])
+	if err != nil || numerator%denominator != 0 || strconv.FormatInt(numerator/denominator, 10) != sides[1] {
+		return ""
+	}
+	return word
+}
+
+func cleanupEquations(words *[]string, length int) *[]string {
+	words = applyToWordSlice(hasOnlyEquationSymbols, words)
+	words = applyToWordSlice(func(word string) string { return hasLength(word, length) }, words)
+	words = applyToWordSlice(isValidEquation, words)
+	return words
+}
+
+// generateEquations returns all valid equations of the given length, sorted.
+func generateEquations(length int) *[]string {
+	equations := []string{}
+	var extend func(expression string)
+	extend = func(expression string) {
+		// a number needs one digit, the result one digit and "=" one symbol
+		for digits := 1; len(expression)+digits+2 <= length; digits++ {
+			low, high := int64(1), int64(10)
+			for idx := 1; idx < digits; idx++ {
+				low, high = low*10, high*10
+			}
+			for number := low; number < high; number++ {
+				longer := expression + strconv.FormatInt(number, 10)
+				if strings.ContainsAny(expression, equationOperators) {
+					numerator, denominator, _ := evaluateExpression(longer)
+					if denominator != 0 && numerator%denominator == 0 && numerator/denominator >= 0 {
+						equation := longer + "=" + strconv.FormatInt(numerator/denominator, 10)
+						if len(equation) == length {
+							equations = append(equations, equation)
+						}
+					}
+				}
+				if len(longer)+4 <= length {
+					for _, operator := range equationOperators {
+						extend(longer + string(operator))
+					}
+				}
+			}
+		}
+	}
+	extend("")
+	sort.Strings(equations)
+	return &equations
+}
+
+// createNerdleGame returns a game over the equations. Nerdle colors repeated
+// symbols like the official Wordle.
+func createNerdleGame(equations *[]string, length int) *WordGame {
+	remainingEquations := make([]string, len(*equations))
+	copy(remainingEquations, *equations)
+	scorer := duplicateScorer{wordleScorer{length: length}}
+	return &WordGame{allWords: equations, remainingWords: &remainingEquations, scorer: scorer}
+}
+
+func nerdle(args []string) error {
+	flags := flag.NewFlagSet("nerdle", flag.ExitOnError)
+	length := flags.Int("length", 8, "number of symbols in the equation, from 5 to 8")
+	path := flags.String("equations", "", "read the equations from `FILE` instead of generating all of them")
+	suggestions := flags.Int("suggestions", 12, "number of best guesses to show")
+	strategy := flags.String("strategy", defaultStrategy, "ranking strategy: "+strings.Join(strategyNames(), ", "))
+	flags.Parse(args)
+	if err := checkStrategy(*strategy); err != nil {
+		return err
+	}
+	// there are too many equations of 9 symbols to rank the guesses
+	if *length < 5 || *length > 8 {
+		return fmt.Errorf("Can't play with equations of length %v, only from 5 to 8", *length)
+	}
+
+	equations := generateEquations(*length)
+	if *path != "" {
+		equations = cleanupEquations(readDictionary(*path), *length)
+	}
+	wg := createNerdleGame(equations, *length)
+	wg.strategy = *strategy
+	fmt.Fprintf(messages, "Calculate best guesses for %v equations ...\n", len(*equations))
+	for len(*wg.remainingWords) > 1 {
+		weights := wg.getGuessWeights()
+		bestGuesses := *getKeysSortedByWeight(&weights)
+		if len(bestGuesses) > *suggestions {
+			bestGuesses = bestGuesses[:*suggestions]
+		}
+		fmt.Fprintf(messages, "Best guesses: %v\n", bestGuesses)
+		if err := suggestionsReport(bestGuesses, weights).emit(); err != nil {
+			return err
+		}
+		guess := readLine("Your guess: ")
+		if hasLength(isValidEquation(guess), *length) == "" {
+			fmt.Fprintf(messages, "Invalid equation '%v'\n", guess)
+			continue
+		}
+		score := readWord("Score of the guess: ", *length, toUniqueScore)
+		wg.guess(guess, score)
+		fmt.Fprintf(messages, "Remaining equations: %v\n", len(*wg.remainingWords))
+		if err := remainingReport(wg.remainingWords).emit(); err != nil {
+			return err
+		}
+	}
+	if len(*wg.remainingWords) == 1 {
+		fmt.Fprintf(messages, "The solution is: %v\n", (*wg.remainingWords)[0])
+	} else {
+		fmt.Fprintln(messages, "No solution found :-(")
+	}
+	if err := solutionReport(wg.remainingWords).emit(); err != nil {
+		return err
+	}
+	readLine("")
+	return nil
+}
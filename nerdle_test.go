@@ -0,0 +1,89 @@
+package main
+
+import "testing"
+
+func TestEvaluateExpression(t *testing.T) {
+	t.Run("with operator precedence", func(t *testing.T) {
+		for expression, reference := range map[string][2]int64{
+			"12+35":  {47, 1},
+			"2+3*4":  {14, 1},
+			"10-2*3": {4, 1},
+			"3/2*4":  {12, 2},
+		} {
+			numerator, denominator, err := evaluateExpression(expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if numerator*reference[1] != reference[0]*denominator {
+				t.Errorf("Expected %v to be %v/%v, got %v/%v", expression, reference[0], reference[1], numerator, denominator)
+			}
+		}
+	})
+
+	t.Run("rejects invalid numbers", func(t *testing.T) {
+		for _, expression := range []string{"01+2", "0*5", "3++4", "+3"} {
+			if _, _, err := evaluateExpression(expression); err == nil {
+				t.Errorf("Expected an error for '%v'", expression)
+			}
+		}
+	})
+}
+
+func TestIsValidEquation(t *testing.T) {
+	for equation, valid := range map[string]bool{
+		"12+35=47": true,
+		"3/2*4=6":  true,
+		"48-48=0":  true,
+		"12+35=48": false,
+		"47=12+35": false,
+		"12=12":    false,
+		"5-9=-4":   false,
+		"7/2=3":    false,
+		"1+1=02":   false,
+		"1+1=2=2":  false,
+	} {
+		if (isValidEquation(equation) != "") != valid {
+			t.Errorf("Expected validity of '%v' to be %v", equation, valid)
+		}
+	}
+}
+
+func TestCleanupEquations(t *testing.T) {
+	t.Run("keeps valid equations of the length", func(t *testing.T) {
+		equations := []string{"12+35=47", "2+3=5", "ab+cd=ef", "12+35=48", "10-2*3=4"}
+		compareWordSlices(t, &[]string{"12+35=47", "10-2*3=4"}, cleanupEquations(&equations, 8))
+	})
+}
+
+func TestGenerateEquations(t *testing.T) {
+	t.Run("all equations are valid", func(t *testing.T) {
+		equations := generateEquations(6)
+		if len(*equations) != 206 {
+			t.Errorf("Expected 206 equations, got %v", len(*equations))
+		}
+		compareWordSlices(t, equations, cleanupEquations(equations, 6))
+	})
+
+	t.Run("the game finds the equation", func(t *testing.T) {
+		wg := createNerdleGame(generateEquations(6), 6)
+		wg.guess("10-5=5", wg.score("10-5=5", "3*4=12"))
+		wg.guess("2*6=12", wg.score("2*6=12", "3*4=12"))
+		if !containsWord(wg.remainingWords, "3*4=12") {
+			t.Errorf("Expected 3*4=12 to remain, got %v", *wg.remainingWords)
+		}
+	})
+}
+
+func TestNerdleScoring(t *testing.T) {
+	wg := createNerdleGame(&[]string{"12+35=47", "11+11=22"}, 8)
+
+	t.Run("colors repeated symbols only as often as they occur", func(t *testing.T) {
+		expectGotString(t, "H.H..Hh.", wg.score("11+11=22", "12+35=47"))
+		expectGotString(t, "H.H..H..", wg.score("11+11=22", "10+35=45"))
+	})
+
+	t.Run("keeps the equation with the repeated symbols", func(t *testing.T) {
+		wg.guess("11+11=22", "H.H..Hh.")
+		compareWordSlices(t, &[]string{"12+35=47"}, wg.remainingWords)
+	})
+}
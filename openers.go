@@ -0,0 +1,258 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// openerScore holds the metrics of a word as first guess. The simulated
+// average is only known if it was requested, otherwise it is negative.
+type openerScore struct {
+	word     string
+	minimax  float64
+	entropy  float64
+	expected float64
+	average  float64
+}
+
+var objectives = []string{"minimax", "entropy", "expected", "simulate"}
+
+// weight returns the value to sort by for an objective, lower is better.
+func (o *openerScore) weight(objective string) float64 {
+	switch objective {
+	case "entropy":
+		return -o.entropy
+	case "expected":
+		return o.expected
+	case "simulate":
+		return o.average
+	}
+	return o.minimax
+}
+
+func (wg *WordGame) scoreOpener(word string) *openerScore {
+	distribution := wg.scoreDistribution(word)
+	return &openerScore{
+		word:     word,
+		minimax:  minimaxWeight(distribution),
+		entropy:  entropy(distribution),
+		expected: expectedSizeWeight(distribution),
+		average:  -1,
+	}
+}
+
+func (o *openerScore) checkpointLine() string {
+	return fmt.Sprintf("%v\t%v\t%v\t%v\t%v\n", o.word, o.minimax, o.entropy, o.expected, o.average)
+}
+
+// checkpointHeader is the first line of a checkpoint, the results are only
+// valid for the same objective and strategy.
+func checkpointHeader(objective, strategy string) string {
+	return fmt.Sprintf("# objective %v, strategy %v\n", objective, strategy)
+}
+
+// readCheckpoint reads the openers evaluated by a previous, possibly
+// interrupted run with the same objective and strategy, and the length of
+// the file without an incomplete last line.
+func readCheckpoint(path, objective, strategy string) (map[string]*openerScore, int64, error) {
+	scores := map[string]*openerScore{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return scores, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	lines := strings.Split(string(data), "\n")
+	// the last line is empty, or incomplete after an interrupted write
+	complete := lines[:len(lines)-1]
+	if len(complete) > 0 {
+		header := checkpointHeader(objective, strategy)
+		if complete[0]+"\n" != header {
+			return nil, 0, fmt.Errorf("Can't resume checkpoint '%v' with '%v', expected '%v'",
+				path, strings.TrimSpace(complete[0]), strings.TrimSpace(header))
+		}
+		complete = complete[1:]
+	}
+	for _, line := range complete {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 5 {
+			return nil, 0, fmt.Errorf("Can't parse checkpoint line '%v'", line)
+		}
+		values := []float64{}
+		for _, field := range fields[1:] {
+			value, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return nil, 0, fmt.Errorf("Can't parse checkpoint line '%v'", line)
+			}
+			values = append(values, value)
+		}
+		scores[fields[0]] = &openerScore{fields[0], values[0], values[1], values[2], values[3]}
+	}
+	return scores, int64(len(data) - len(lines[len(lines)-1])), nil
+}
+
+type openerSearch struct {
+	guesses   *[]string
+	solutions *[]string
+	objective string
+	strategy  string
+	progress  io.Writer
+	done      map[string]*openerScore
+}
+
+func (s *openerSearch) report(evaluated, total int, start time.Time, resumed int) {
+	if s.progress == nil {
+		return
+	}
+	eta := "?"
+	if evaluated > resumed {
+		perWord := time.Since(start) / time.Duration(evaluated-resumed)
+		eta = (perWord * time.Duration(total-evaluated)).Round(time.Second).String()
+	}
+	fmt.Fprintf(s.progress, "\rEvaluated %v/%v openers, ETA %v   ", evaluated, total, eta)
+	if evaluated == total {
+		fmt.Fprintln(s.progress)
+	}
+}
+
+// run evaluates all words as openers, skipping the ones found in the
+// checkpoint, and appends every new result to the checkpoint.
+func (s *openerSearch) run(checkpoint io.Writer) (*[]openerScore, error) {
+	wg := &WordGame{allWords: s.guesses, remainingWords: s.solutions}
+	scores := []openerScore{}
+	start, resumed := time.Now(), 0
+	for idx, word := range *s.guesses {
+		score, ok := s.done[word]
+		if ok && (s.objective != "simulate" || score.average >= 0) {
+			resumed += 1
+		} else {
+			score = wg.scoreOpener(word)
+			if s.objective == "simulate" {
+				sim := newSimulator(s.guesses, s.solutions, s.strategy, word)
+				score.average, _, _, _ = summarizeTurns(sim.playAll(s.solutions), maxSimulatedTurns)
+			}
+			if checkpoint != nil {
+				if _, err := io.WriteString(checkpoint, score.checkpointLine()); err != nil {
+					return nil, err
+				}
+			}
+		}
+		scores = append(scores, *score)
+		if idx%100 == 99 || idx == len(*s.guesses)-1 || s.objective == "simulate" {
+			s.report(idx+1, len(*s.guesses), start, resumed)
+		}
+	}
+	sort.SliceStable(scores, func(i, j int) bool {
+		a, b := scores[i].weight(s.objective), scores[j].weight(s.objective)
+		if a != b {
+			return a < b
+		}
+		return scores[i].word < scores[j].word
+	})
+	return &scores, nil
+}
+
+// prefilterOpeners returns the given number of words with the highest
+// entropy as first guess.
+func prefilterOpeners(guesses, solutions *[]string, count int) *[]string {
+	if count <= 0 || count >= len(*guesses) {
+		return guesses
+	}
+	wg := &WordGame{allWords: guesses, remainingWords: solutions, strategy: "entropy"}
+	best := (*wg.getBestGuesses())[:count]
+	return &best
+}
+
+func openers(args []string) error {
+	flags := flag.NewFlagSet("openers", flag.ExitOnError)
+	objective := flags.String("objective", "minimax", "objective: "+strings.Join(objectives, ", "))
+	top := flags.Int("top", 20, "number of openers to show")
+	strategy := flags.String("strategy", defaultStrategy, "strategy after the opener, for the simulate objective")
+	candidates := flags.Int("candidates", 0, "only evaluate this many openers with the highest entropy, 0 for all")
+	checkpointPath := flags.String("checkpoint", "", "save results to `FILE` and resume from it after an interruption")
+	flags.Parse(args)
+
+	known := false
+	for _, name := range objectives {
+		known = known || name == *objective
+	}
+	if !known {
+		return fmt.Errorf("Unknown objective '%v', expected one of %v", *objective, strings.Join(objectives, ", "))
+	}
+	if err := checkStrategy(*strategy); err != nil {
+		return err
+	}
+
+	solutions := applyToWordSlice(strings.ToUpper, &possibleSolutions)
+	search := &openerSearch{
+		guesses:   prefilterOpeners(defaultGuesses(), solutions, *candidates),
+		solutions: solutions,
+		objective: *objective,
+		strategy:  *strategy,
+		progress:  os.Stderr,
+		done:      map[string]*openerScore{},
+	}
+	var checkpoint io.Writer
+	if *checkpointPath != "" {
+		done, length, err := readCheckpoint(*checkpointPath, *objective, *strategy)
+		if err != nil {
+			return err
+		}
+		search.done = done
+		file, err := os.OpenFile(*checkpointPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		if err := file.Truncate(length); err != nil {
+			return err
+		}
+		defer file.Close()
+		if length == 0 {
+			if _, err := io.WriteString(file, checkpointHeader(*objective, *strategy)); err != nil {
+				return err
+			}
+		}
+		checkpoint = file
+	}
+
+	scores, err := search.run(checkpoint)
+	if err != nil {
+		return err
+	}
+	if machineOutput() {
+		r := newReport("openers", "rank", "opener", "minimax", "entropy", "expected", "average")
+		for idx, score := range *scores {
+			if idx == *top {
+				break
+			}
+			// the average is only known for the simulate objective
+			var average interface{}
+			if score.average >= 0 {
+				average = score.average
+			}
+			r.add(idx+1, score.word, score.minimax, score.entropy, score.expected, average)
+		}
+		return r.emit()
+	}
+	w := tabwriter.NewWriter(messages, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Rank\tOpener\tMinimax\tEntropy\tExpected\tAverage")
+	for idx, score := range *scores {
+		if idx == *top {
+			break
+		}
+		average := "-"
+		if score.average >= 0 {
+			average = fmt.Sprintf("%.4f", score.average)
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%.4f\t%.2f\t%v\n", idx+1, score.word, score.minimax, score.entropy, score.expected, average)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenerSearch(t *testing.T) {
+	guesses := []string{"AXY", "BXY", "CXY", "ABC", "XXX"}
+	solutions := []string{"AXY", "BXY", "CXY"}
+
+	t.Run("sorted by objective", func(t *testing.T) {
+		search := &openerSearch{guesses: &guesses, solutions: &solutions, objective: "expected", done: map[string]*openerScore{}}
+		scores, err := search.run(nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expectGotString(t, "ABC", (*scores)[0].word)
+		expectGotFloat(t, 1, (*scores)[0].expected)
+		expectGotString(t, "XXX", (*scores)[4].word)
+	})
+
+	t.Run("with simulated average", func(t *testing.T) {
+		search := &openerSearch{guesses: &guesses, solutions: &solutions, objective: "simulate", strategy: "minimax", done: map[string]*openerScore{}}
+		scores, _ := search.run(nil)
+		expectGotString(t, "ABC", (*scores)[0].word)
+		expectGotFloat(t, 2, (*scores)[0].average)
+	})
+
+	t.Run("resumed from checkpoint", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "openers.tsv")
+		var checkpoint bytes.Buffer
+		checkpoint.WriteString(checkpointHeader("minimax", "entropy"))
+		first := &openerSearch{guesses: &guesses, solutions: &solutions, objective: "minimax", done: map[string]*openerScore{}}
+		first.run(&checkpoint)
+		// simulate an interruption during the last write
+		os.WriteFile(path, checkpoint.Bytes()[:checkpoint.Len()-3], 0644)
+
+		done, length, err := readCheckpoint(path, "minimax", "entropy")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if length != int64(checkpoint.Len()-len("XXX\t3\t0\t3\t-1\n")) {
+			t.Errorf("Expected the incomplete line to be cut off, got length %v", length)
+		}
+		if len(done) != 4 {
+			t.Fatalf("Expected 4 evaluated openers got %v", len(done))
+		}
+		var rest bytes.Buffer
+		second := &openerSearch{guesses: &guesses, solutions: &solutions, objective: "minimax", done: done}
+		scores, _ := second.run(&rest)
+		expectGotString(t, "XXX\t3\t0\t3\t-1\n", rest.String())
+		expectGotString(t, "ABC", (*scores)[0].word)
+	})
+
+	t.Run("resumed with other settings", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "openers.tsv")
+		os.WriteFile(path, []byte(checkpointHeader("minimax", "entropy")+"ABC\t1\t1.5\t1\t-1\n"), 0644)
+		if _, _, err := readCheckpoint(path, "simulate", "entropy"); err == nil {
+			t.Errorf("Expected an error for a different objective")
+		}
+		if _, _, err := readCheckpoint(path, "minimax", "minimax"); err == nil {
+			t.Errorf("Expected an error for a different strategy")
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// The json and tsv output formats write the results of every command as
+// reports to stdout, and all other output, like prompts and messages, to
+// stderr. The text format writes everything for humans to stdout.
+
+var outputFormats = []string{"text", "json", "tsv"}
+
+var outputFormat = "text"
+
+// reports is where the reports are written, and messages where everything
+// else for humans is written.
+var reports io.Writer = os.Stdout
+var messages io.Writer = os.Stdout
+
+func useOutput(format string) error {
+	if !containsWord(&outputFormats, format) {
+		return fmt.Errorf("Unknown output format '%v', expected one of %v", format, strings.Join(outputFormats, ", "))
+	}
+	outputFormat = format
+	messages = os.Stdout
+	if format != "text" {
+		messages = os.Stderr
+	}
+	return nil
+}
+
+func machineOutput() bool {
+	return outputFormat != "text"
+}
+
+// report is a result of a command, like the suggested guesses, as a table.
+// Its kind and columns are its schema and don't change between versions.
+type report struct {
+	kind    string
+	columns []string
+	rows    [][]interface{}
+}
+
+func newReport(kind string, columns ...string) *report {
+	return &report{kind: kind, columns: columns, rows: [][]interface{}{}}
+}
+
+func (r *report) add(values ...interface{}) {
+	if len(values) != len(r.columns) {
+		panic(fmt.Errorf("Can't add %v values to report '%v' with %v columns", len(values), r.kind, len(r.columns)))
+	}
+	r.rows = append(r.rows, values)
+}
+
+// writeJSON writes the report as one line of JSON, with an object for every
+// row.
+func (r *report) writeJSON(w io.Writer) error {
+	rows := []map[string]interface{}{}
+	for _, values := range r.rows {
+		row := map[string]interface{}{}
+		for idx, column := range r.columns {
+			row[column] = values[idx]
+		}
+		rows = append(rows, row)
+	}
+	data, err := json.Marshal(map[string]interface{}{"kind": r.kind, "rows": rows})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
+
+// writeTSV writes the report as a table with the kind as first column.
+func (r *report) writeTSV(w io.Writer) error {
+	fmt.Fprintf(w, "kind\t%v\n", strings.Join(r.columns, "\t"))
+	for _, values := range r.rows {
+		fields := []string{r.kind}
+		for _, value := range values {
+			fields = append(fields, tsvField(value))
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(fields, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func tsvField(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []string:
+		return strings.Join(v, ",")
+	case float64:
+		return fmt.Sprintf("%.4f", v)
+	}
+	return strings.NewReplacer("\t", " ", "\n", " ").Replace(fmt.Sprint(value))
+}
+
+// emit writes the report in the chosen machine readable format. In the text
+// format the commands print their results themselves.
+func (r *report) emit() error {
+	switch outputFormat {
+	case "json":
+		return r.writeJSON(reports)
+	case "tsv":
+		return r.writeTSV(reports)
+	}
+	return nil
+}
+
+// suggestionsReport lists the best guesses with their weights.
+func suggestionsReport(guesses []string, weights map[string]float64) *report {
+	r := newReport("suggestions", "rank", "guess", "weight")
+	for idx, guess := range guesses {
+		r.add(idx+1, guess, weights[guess])
+	}
+	return r
+}
+
+// remainingReport lists the words that are still possible.
+func remainingReport(words *[]string) *report {
+	r := newReport("remaining", "word")
+	for _, word := range *words {
+		r.add(word)
+	}
+	return r
+}
+
+// solutionReport contains the solution, if only one word remains.
+func solutionReport(words *[]string) *report {
+	r := newReport("solution", "solution")
+	if len(*words) == 1 {
+		r.add((*words)[0])
+	}
+	return r
+}
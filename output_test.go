@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	r := newReport("suggestions", "rank", "guess", "weight")
+	r.add(1, "AESIR", 168.0)
+	r.add(2, "REAIS", 168.5)
+
+	t.Run("as JSON", func(t *testing.T) {
+		var buffer bytes.Buffer
+		if err := r.writeJSON(&buffer); err != nil {
+			t.Fatal(err)
+		}
+		expectGotString(t, `{"kind":"suggestions","rows":[{"guess":"AESIR","rank":1,"weight":168},{"guess":"REAIS","rank":2,"weight":168.5}]}`+"\n", buffer.String())
+	})
+
+	t.Run("as TSV", func(t *testing.T) {
+		var buffer bytes.Buffer
+		if err := r.writeTSV(&buffer); err != nil {
+			t.Fatal(err)
+		}
+		expectGotString(t, "kind\trank\tguess\tweight\nsuggestions\t1\tAESIR\t168.0000\nsuggestions\t2\tREAIS\t168.5000\n", buffer.String())
+	})
+
+	t.Run("empty as JSON", func(t *testing.T) {
+		var buffer bytes.Buffer
+		solutionReport(&[]string{"CRANE", "TRACE"}).writeJSON(&buffer)
+		expectGotString(t, `{"kind":"solution","rows":[]}`+"\n", buffer.String())
+	})
+}
+
+func TestTSVField(t *testing.T) {
+	expectGotString(t, "A,B", tsvField([]string{"A", "B"}))
+	expectGotString(t, "", tsvField(nil))
+	expectGotString(t, "a b", tsvField("a\tb"))
+	expectGotString(t, "true", tsvField(true))
+}
+
+func TestUseOutput(t *testing.T) {
+	t.Run("unknown format", func(t *testing.T) {
+		if err := useOutput("yaml"); err == nil {
+			t.Errorf("Expected an error for an unknown format")
+		}
+		expectGotString(t, "text", outputFormat)
+	})
+
+	t.Run("several formats", func(t *testing.T) {
+		defer useOutput("text")
+		useOutput("json")
+		useOutput("tsv")
+		if reports != os.Stdout || messages != os.Stderr {
+			t.Errorf("Expected reports on stdout and messages on stderr")
+		}
+		useOutput("text")
+		if reports != os.Stdout || messages != os.Stdout {
+			t.Errorf("Expected reports and messages on stdout")
+		}
+	})
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"runtime"
+	"sync"
+)
+
+// parallelFor calls f for all indices below n on all CPUs.
+func parallelFor(n int, f func(idx int)) {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for worker := 0; worker < runtime.NumCPU(); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				f(idx)
+			}
+		}()
+	}
+	for idx := 0; idx < n; idx++ {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+}
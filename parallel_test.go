@@ -0,0 +1,20 @@
+package main
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestParallelFor(t *testing.T) {
+	t.Run("calls every index once", func(t *testing.T) {
+		calls := make([]int32, 100)
+		parallelFor(len(calls), func(idx int) {
+			atomic.AddInt32(&calls[idx], 1)
+		})
+		for idx, count := range calls {
+			if count != 1 {
+				t.Errorf("Expected one call for index %v got %v", idx, count)
+			}
+		}
+	})
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// guessPool selects the guesses that are rated. In guarantee mode the pruned
+// guesses are checked too, unless they provably can't beat the best guess of
+// the pool.
+type guessPool struct {
+	mode      string
+	size      int
+	guarantee bool
+}
+
+var guessPoolModes = []string{"all", "shared", "remaining", "coverage:N"}
+
+// parseGuessPool parses all, shared (guesses sharing a letter with the
+// remaining words), remaining (only the remaining words) or coverage:N (the
+// N guesses covering the most common letters of the remaining words).
+func parseGuessPool(input string, guarantee bool) (guessPool, error) {
+	pool := guessPool{mode: input, guarantee: guarantee}
+	if strings.HasPrefix(input, "coverage:") {
+		size, err := strconv.Atoi(strings.TrimPrefix(input, "coverage:"))
+		if err != nil || size < 1 {
+			return pool, fmt.Errorf("Can't parse pool size in '%v'", input)
+		}
+		pool.mode, pool.size = "coverage", size
+		return pool, nil
+	}
+	if input != "all" && input != "shared" && input != "remaining" {
+		return pool, fmt.Errorf("Unknown guess pool '%v', expected one of %v", input, strings.Join(guessPoolModes, ", "))
+	}
+	return pool, nil
+}
+
+// guessPool returns the guesses of the pool and the pruned guesses.
+func (wg *WordGame) guessPool() (*[]string, *[]string) {
+	pool, pruned := []string{}, []string{}
+	switch wg.pool.mode {
+	case "shared":
+		letters := letterFrequencies(wg.remainingWords)
+		for _, word := range *wg.allWords {
+			shared := false
+			for _, letter := range word {
+				shared = shared || letters[letter] > 0
+			}
+			if shared {
+				pool = append(pool, word)
+			} else {
+				pruned = append(pruned, word)
+			}
+		}
+	case "remaining":
+		pool = append(pool, *wg.remainingWords...)
+		pruned = *excludeWords(wg.allWords, wg.remainingWords)
+	case "coverage":
+		ordered := *sortByLetterFrequencyAmong(wg.allWords, wg.remainingWords)
+		if wg.pool.size < len(ordered) {
+			pool, pruned = ordered[:wg.pool.size], ordered[wg.pool.size:]
+		} else {
+			pool = ordered
+		}
+	default:
+		return wg.allWords, &pruned
+	}
+	return &pool, &pruned
+}
+
+// weightBounds returns the lowest weight a guess can have for the number of
+// remaining words, if it can produce at most the given number of scores.
+var weightBounds = map[string]func(words, scores int) float64{
+	"minimax": func(words, scores int) float64 {
+		return math.Ceil(float64(words) / float64(scores))
+	},
+	"entropy": func(words, scores int) float64 {
+		return -math.Log2(float64(scores))
+	},
+	"expected": func(words, scores int) float64 {
+		return float64(words) / float64(scores)
+	},
+}
+
+// scoreOptions tells for every position and letter whether a guess with the
+// letter at the position can get a green, yellow or grey tile.
+type scoreOptions struct {
+	green  []map[rune]bool
+	yellow []map[rune]bool
+	grey   map[rune]bool
+}
+
+func newScoreOptions(remainingWords *[]string) *scoreOptions {
+	options := &scoreOptions{grey: map[rune]bool{}}
+	letters := letterFrequencies(remainingWords)
+	for _, word := range *remainingWords {
+		for len(options.green) < len(word) {
+			options.green = append(options.green, map[rune]bool{})
+			options.yellow = append(options.yellow, map[rune]bool{})
+		}
+		for idx, letter := range word {
+			options.green[idx][letter] = true
+			for other := range word {
+				if word[other] != byte(letter) {
+					options.yellow[other][letter] = true
+				}
+			}
+		}
+		for letter := range letters {
+			if !strings.ContainsRune(word, letter) {
+				options.grey[letter] = true
+			}
+		}
+	}
+	return options
+}
+
+// maxScores returns an upper bound for the number of different scores the
+// guess can get against the remaining words.
+func (o *scoreOptions) maxScores(guess string, words int) int {
+	scores := 1
+	for idx, letter := range guess {
+		count := 0
+		if idx < len(o.green) && o.green[idx][letter] {
+			count += 1
+		}
+		if idx < len(o.yellow) && o.yellow[idx][letter] {
+			count += 1
+		}
+		if o.grey[letter] || idx >= len(o.green) || !o.green[idx][letter] && !o.yellow[idx][letter] {
+			count += 1
+		}
+		scores *= count
+		if scores >= words {
+			return words
+		}
+	}
+	return scores
+}
+
+// unprovenGuesses returns the pruned guesses that might beat the best weight.
+func (wg *WordGame) unprovenGuesses(pruned *[]string, best float64) *[]string {
+	if wg.lies > 0 {
+		// a lying score can be any of several scores, so there is no bound
+		return pruned
+	}
+	unproven := []string{}
+	words := len(*wg.remainingWords)
+	if words == 0 {
+		return &unproven
+	}
+	bound := weightBounds[wg.strategyName()]
+	options := newScoreOptions(wg.remainingWords)
+	for _, guess := range *pruned {
+		if bound(words, options.maxScores(guess, words)) < best {
+			unproven = append(unproven, guess)
+		}
+	}
+	return &unproven
+}
@@ -0,0 +1,80 @@
+package main
+
+import "testing"
+
+func TestParseGuessPool(t *testing.T) {
+	t.Run("modes", func(t *testing.T) {
+		pool, err := parseGuessPool("coverage:50", true)
+		if err != nil || pool.mode != "coverage" || pool.size != 50 || !pool.guarantee {
+			t.Errorf("Unexpected pool %+v, %v", pool, err)
+		}
+		for _, input := range []string{"all", "shared", "remaining"} {
+			if _, err := parseGuessPool(input, false); err != nil {
+				t.Errorf("Unexpected error for '%v': %v", input, err)
+			}
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, input := range []string{"some", "coverage:", "coverage:0"} {
+			if _, err := parseGuessPool(input, false); err == nil {
+				t.Errorf("Expected an error for '%v'", input)
+			}
+		}
+	})
+}
+
+func TestGuessPool(t *testing.T) {
+	allWords := []string{"ABC", "XYZ", "ABD", "DEF"}
+	remaining := []string{"ABC", "ABD"}
+
+	t.Run("shared letters", func(t *testing.T) {
+		wg := &WordGame{allWords: &allWords, remainingWords: &remaining, pool: guessPool{mode: "shared"}}
+		pool, pruned := wg.guessPool()
+		compareWordSlices(t, &[]string{"ABC", "ABD", "DEF"}, pool)
+		compareWordSlices(t, &[]string{"XYZ"}, pruned)
+	})
+
+	t.Run("remaining words", func(t *testing.T) {
+		wg := &WordGame{allWords: &allWords, remainingWords: &remaining, pool: guessPool{mode: "remaining"}}
+		pool, pruned := wg.guessPool()
+		compareWordSlices(t, &remaining, pool)
+		compareWordSlices(t, &[]string{"XYZ", "DEF"}, pruned)
+	})
+
+	t.Run("letter coverage", func(t *testing.T) {
+		wg := &WordGame{allWords: &allWords, remainingWords: &remaining, pool: guessPool{mode: "coverage", size: 2}}
+		pool, pruned := wg.guessPool()
+		compareWordSlices(t, &[]string{"ABC", "ABD"}, pool)
+		compareWordSlices(t, &[]string{"DEF", "XYZ"}, pruned)
+	})
+}
+
+func TestMaxScores(t *testing.T) {
+	t.Run("bounds the distinct scores", func(t *testing.T) {
+		guesses := (*cleanupWords(&allWords, 5))[:300]
+		remaining := (*cleanupWords(&possibleSolutions, 5))[:200]
+		wg := &WordGame{allWords: &guesses, remainingWords: &remaining}
+		options := newScoreOptions(&remaining)
+		for _, guess := range guesses {
+			if distinct := len(wg.scoreDistribution(guess)); options.maxScores(guess, len(remaining)) < distinct {
+				t.Errorf("Expected at least %v scores for %v, got %v", distinct, guess, options.maxScores(guess, len(remaining)))
+			}
+		}
+	})
+}
+
+func TestGuarantee(t *testing.T) {
+	guesses := defaultGuesses()
+	wg := createWordGameFromWordLists(guesses, &possibleSolutions)
+	wg.guess("RAISE", scoreAgainst("RAISE", "CLOUT"))
+
+	for _, strategy := range strategyNames() {
+		t.Run(strategy+" finds the best guess", func(t *testing.T) {
+			full := &WordGame{allWords: wg.allWords, remainingWords: wg.remainingWords, strategy: strategy}
+			pruned := *full
+			pruned.pool = guessPool{mode: "coverage", size: 20, guarantee: true}
+			expectGotString(t, (*full.getBestGuesses())[0], (*pruned.getBestGuesses())[0])
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// progressFunc is called while guesses are ranked, with the number of
+// guesses evaluated so far. It may be called from several goroutines.
+type progressFunc func(evaluated, total int)
+
+// progressBar draws the progress of a ranking on a single terminal line, and
+// clears it when the ranking is done.
+type progressBar struct {
+	w        io.Writer
+	width    int
+	interval time.Duration
+	mutex    sync.Mutex
+	start    time.Time
+	drawn    time.Time
+}
+
+func newProgressBar(w io.Writer) *progressBar {
+	return &progressBar{w: w, width: 30, interval: 100 * time.Millisecond}
+}
+
+// isTerminal tells whether the writer is a terminal, where the progress bar
+// can overwrite itself.
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func (p *progressBar) render(evaluated, total int, elapsed time.Duration) string {
+	filled := p.width * evaluated / total
+	eta := "?"
+	if evaluated > 0 {
+		eta = (elapsed * time.Duration(total-evaluated) / time.Duration(evaluated)).Round(time.Second).String()
+	}
+	return fmt.Sprintf("[%v%v] %3d%% %v/%v ETA %v",
+		strings.Repeat("#", filled), strings.Repeat("-", p.width-filled), 100*evaluated/total, evaluated, total, eta)
+}
+
+func (p *progressBar) update(evaluated, total int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	now := time.Now()
+	if p.start.IsZero() || evaluated == 1 {
+		// wait one interval before the first estimate
+		p.start, p.drawn = now, now
+	}
+	if evaluated == total {
+		fmt.Fprintf(p.w, "\r%v\r", strings.Repeat(" ", len(p.render(total, total, 0))+10))
+		p.start, p.drawn = time.Time{}, time.Time{}
+		return
+	}
+	if now.Sub(p.drawn) < p.interval {
+		return
+	}
+	p.drawn = now
+	fmt.Fprintf(p.w, "\r%v", p.render(evaluated, total, now.Sub(p.start)))
+}
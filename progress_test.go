@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestProgressBar(t *testing.T) {
+	t.Run("render", func(t *testing.T) {
+		p := &progressBar{width: 10}
+		expectGotString(t, "[##--------]  25% 25/100 ETA 3s", p.render(25, 100, time.Second))
+		expectGotString(t, "[----------]   0% 0/100 ETA ?", p.render(0, 100, 0))
+	})
+
+	t.Run("clears the line when done", func(t *testing.T) {
+		var buffer bytes.Buffer
+		p := newProgressBar(&buffer)
+		p.interval = 0
+		p.update(1, 2)
+		p.update(2, 2)
+		if !strings.HasSuffix(buffer.String(), " \r") || strings.Contains(buffer.String(), "ETA ?") {
+			t.Errorf("Expected a cleared line, got %q", buffer.String())
+		}
+	})
+}
+
+func TestRankingProgress(t *testing.T) {
+	t.Run("reports every guess", func(t *testing.T) {
+		words := []string{"ABC", "ACB", "EAD", "FED"}
+		wg := createWordGameFromWordLists(&words, &words)
+		calls, last := 0, 0
+		var mutex sync.Mutex
+		wg.progress = func(evaluated, total int) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			calls += 1
+			if evaluated > last {
+				last = evaluated
+			}
+			if total != len(words) {
+				t.Errorf("Expected total %v got %v", len(words), total)
+			}
+		}
+		wg.getBestGuesses()
+		if calls != len(words) || last != len(words) {
+			t.Errorf("Expected %v reports, got %v up to %v", len(words), calls, last)
+		}
+	})
+}
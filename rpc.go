@@ -0,0 +1,306 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// The rpc command speaks JSON-RPC 2.0 over stdin and stdout, one request or
+// response per line, so editor plugins can keep a solver running. The
+// weights of the first guess are computed once per setting and shared by all
+// games.
+
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcGame is a game of the server, with the remaining words before every
+// turn, so turns can be undone.
+type rpcGame struct {
+	wg       *WordGame
+	settings sessionSettings
+	turns    []sessionTurn
+	previous []*[]string
+	// solutions are the solutions at the start, valid guesses like the
+	// guesses of the game
+	solutions *[]string
+}
+
+type rpcServer struct {
+	games    map[string]*rpcGame
+	nextGame int
+	openings map[string]map[string]float64
+}
+
+func newRPCServer() *rpcServer {
+	return &rpcServer{games: map[string]*rpcGame{}, nextGame: 1, openings: map[string]map[string]float64{}}
+}
+
+type rpcGameParams struct {
+	Game string `json:"game"`
+}
+
+type rpcGuessParams struct {
+	Game  string `json:"game"`
+	Guess string `json:"guess"`
+	Score string `json:"score"`
+}
+
+type rpcListParams struct {
+	Game  string `json:"game"`
+	Count int    `json:"count"`
+}
+
+type rpcSuggestion struct {
+	Guess  string  `json:"guess"`
+	Weight float64 `json:"weight"`
+}
+
+var rpcMethods = map[string]func(s *rpcServer, params json.RawMessage) (interface{}, error){
+	"newGame":     (*rpcServer).newGame,
+	"guess":       (*rpcServer).guess,
+	"suggestions": (*rpcServer).suggestions,
+	"remaining":   (*rpcServer).remaining,
+	"undo":        (*rpcServer).undo,
+}
+
+func invalidParams(format string, args ...interface{}) error {
+	return &rpcError{rpcInvalidParams, fmt.Sprintf(format, args...)}
+}
+
+func decodeParams(params json.RawMessage, v interface{}) error {
+	if len(params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return invalidParams("Can't parse params: %v", err)
+	}
+	return nil
+}
+
+func (s *rpcServer) game(id string) (*rpcGame, error) {
+	game, ok := s.games[id]
+	if !ok {
+		return nil, invalidParams("Unknown game '%v'", id)
+	}
+	return game, nil
+}
+
+func (s *rpcServer) newGame(params json.RawMessage) (interface{}, error) {
+	settings := sessionSettings{Suggestions: 12, Strategy: defaultStrategy}
+	if err := decodeParams(params, &settings); err != nil {
+		return nil, err
+	}
+	if err := checkStrategy(settings.Strategy); err != nil {
+		return nil, invalidParams("%v", err)
+	}
+	if settings.Lies < 0 || settings.Lies > dictionaryWordLength {
+		return nil, invalidParams("Can't play with %v lies in a score of length %v", settings.Lies, dictionaryWordLength)
+	}
+	solutions := excludeWords(&possibleSolutions, &settings.ExcludedAnswers)
+	guesses, solutions, _ := canonicalWordLists(&allWords, solutions, settings.AddSolutions)
+	wg := &WordGame{allWords: guesses, remainingWords: solutions, strategy: settings.Strategy, lies: settings.Lies}
+
+	id := strconv.Itoa(s.nextGame)
+	s.nextGame += 1
+	s.games[id] = &rpcGame{wg: wg, settings: settings, turns: []sessionTurn{}, solutions: solutions}
+	return map[string]interface{}{"game": id, "remaining": len(*solutions), "guesses": len(*guesses)}, nil
+}
+
+func (s *rpcServer) guess(params json.RawMessage) (interface{}, error) {
+	p := rpcGuessParams{}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	game, err := s.game(p.Game)
+	if err != nil {
+		return nil, err
+	}
+	guess, score := strings.ToUpper(p.Guess), p.Score
+	length := len((*game.wg.allWords)[0])
+	if len(guess) != length || len(score) != length {
+		return nil, invalidParams("Guess '%v' and score '%v' need %v letters", p.Guess, p.Score, length)
+	}
+	if !containsWord(game.wg.allWords, guess) && !containsWord(game.solutions, guess) {
+		return nil, invalidParams("Invalid guess '%v', it's not one of the guesses", p.Guess)
+	}
+	if strings.Trim(score, ".hH"+string(unknownTile)) != "" {
+		return nil, invalidParams("Invalid score '%v', expected only . h H and %c", p.Score, unknownTile)
+	}
+	game.previous = append(game.previous, game.wg.remainingWords)
+	game.turns = append(game.turns, sessionTurn{Guess: guess, Score: score})
+	game.wg.guess(guess, score)
+	// with lies an all-hit score may be a lie itself, the game goes on until
+	// a single word remains
+	return map[string]interface{}{
+		"remaining": len(*game.wg.remainingWords),
+		"solved":    score == strings.Repeat("H", length) && game.wg.lies == 0,
+	}, nil
+}
+
+// openingKey identifies the settings of a game before the first guess.
+func openingKey(settings sessionSettings) string {
+	return fmt.Sprintf("%v/%v/%v/%v", settings.Strategy, settings.Lies, settings.AddSolutions, strings.Join(settings.ExcludedAnswers, ","))
+}
+
+func (s *rpcServer) suggestions(params json.RawMessage) (interface{}, error) {
+	p := rpcListParams{}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	game, err := s.game(p.Game)
+	if err != nil {
+		return nil, err
+	}
+	if p.Count <= 0 {
+		p.Count = game.settings.Suggestions
+	}
+	var weights map[string]float64
+	if len(game.turns) == 0 {
+		key := openingKey(game.settings)
+		if _, ok := s.openings[key]; !ok {
+			s.openings[key] = game.wg.getGuessWeights()
+		}
+		weights = s.openings[key]
+	} else {
+		weights = game.wg.getGuessWeights()
+	}
+	suggestions := []rpcSuggestion{}
+	for _, guess := range *getKeysSortedByWeight(&weights) {
+		if len(suggestions) == p.Count {
+			break
+		}
+		suggestions = append(suggestions, rpcSuggestion{guess, weights[guess]})
+	}
+	return map[string]interface{}{"suggestions": suggestions}, nil
+}
+
+func (s *rpcServer) remaining(params json.RawMessage) (interface{}, error) {
+	p := rpcListParams{}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	game, err := s.game(p.Game)
+	if err != nil {
+		return nil, err
+	}
+	words := *game.wg.remainingWords
+	if p.Count > 0 && p.Count < len(words) {
+		words = words[:p.Count]
+	}
+	return map[string]interface{}{"count": len(*game.wg.remainingWords), "words": words}, nil
+}
+
+func (s *rpcServer) undo(params json.RawMessage) (interface{}, error) {
+	p := rpcGameParams{}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	game, err := s.game(p.Game)
+	if err != nil {
+		return nil, err
+	}
+	if len(game.turns) == 0 {
+		return nil, invalidParams("Game '%v' has no turn to undo", p.Game)
+	}
+	last := len(game.turns) - 1
+	undone := game.turns[last]
+	game.wg.remainingWords = game.previous[last]
+	game.turns, game.previous = game.turns[:last], game.previous[:last]
+	return map[string]interface{}{
+		"undone":    undone,
+		"remaining": len(*game.wg.remainingWords),
+		"turns":     len(game.turns),
+	}, nil
+}
+
+// handle answers a request line. Notifications, requests without an id,
+// get no answer unless they are invalid.
+func (s *rpcServer) handle(line []byte) *rpcResponse {
+	if bytes.HasPrefix(bytes.TrimSpace(line), []byte("[")) {
+		return &rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{rpcInvalidRequest, "Batches of requests aren't supported"}}
+	}
+	request := rpcRequest{}
+	if err := json.Unmarshal(line, &request); err != nil {
+		return &rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{rpcParseError, err.Error()}}
+	}
+	response := &rpcResponse{JSONRPC: "2.0", ID: request.ID}
+	if len(request.ID) == 0 {
+		response.ID = json.RawMessage("null")
+	}
+	method, ok := rpcMethods[request.Method]
+	if request.JSONRPC != "2.0" || request.Method == "" {
+		response.Error = &rpcError{rpcInvalidRequest, "Expected a JSON-RPC 2.0 request with a method"}
+	} else if !ok {
+		response.Error = &rpcError{rpcMethodNotFound, fmt.Sprintf("Unknown method '%v'", request.Method)}
+	} else if result, err := method(s, request.Params); err != nil {
+		response.Error, ok = err.(*rpcError)
+		if !ok {
+			response.Error = &rpcError{rpcInvalidParams, err.Error()}
+		}
+	} else {
+		response.Result = result
+	}
+	if len(request.ID) == 0 && (response.Error == nil || response.Error.Code != rpcInvalidRequest) {
+		return nil
+	}
+	return response
+}
+
+func (s *rpcServer) serve(r *bufio.Reader, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(strings.TrimSpace(string(line))) > 0 {
+			if response := s.handle(line); response != nil {
+				if err := encoder.Encode(response); err != nil {
+					return err
+				}
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func rpc(args []string) error {
+	flags := flag.NewFlagSet("rpc", flag.ExitOnError)
+	flags.Parse(args)
+	return newRPCServer().serve(stdin, reports)
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func callRPC(t *testing.T, s *rpcServer, request string) map[string]interface{} {
+	response := s.handle([]byte(request))
+	if response == nil {
+		t.Fatalf("Expected a response to %v", request)
+	}
+	data, err := json.Marshal(response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := map[string]interface{}{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	return decoded
+}
+
+func rpcResult(t *testing.T, s *rpcServer, request string) map[string]interface{} {
+	response := callRPC(t, s, request)
+	result, ok := response["result"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected a result for %v got %v", request, response)
+	}
+	return result
+}
+
+func rpcErrorCode(t *testing.T, s *rpcServer, request string) float64 {
+	response := callRPC(t, s, request)
+	rpcErr, ok := response["error"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected an error for %v got %v", request, response)
+	}
+	return rpcErr["code"].(float64)
+}
+
+func TestRPCServer(t *testing.T) {
+	t.Run("plays and undoes turns", func(t *testing.T) {
+		s := newRPCServer()
+		game := rpcResult(t, s, `{"jsonrpc":"2.0","id":1,"method":"newGame"}`)
+		expectGotString(t, "1", game["game"].(string))
+		start := game["remaining"].(float64)
+
+		first := rpcResult(t, s, `{"jsonrpc":"2.0","id":2,"method":"guess","params":{"game":"1","guess":"raise","score":"..h.."}}`)
+		afterFirst := first["remaining"].(float64)
+		if afterFirst >= start || first["solved"].(bool) {
+			t.Errorf("Expected fewer than %v unsolved got %v", start, first)
+		}
+		second := rpcResult(t, s, `{"jsonrpc":"2.0","id":3,"method":"guess","params":{"game":"1","guess":"count","score":"hh..."}}`)
+
+		remaining := rpcResult(t, s, `{"jsonrpc":"2.0","id":4,"method":"remaining","params":{"game":"1","count":2}}`)
+		expectGotFloat(t, second["remaining"].(float64), remaining["count"].(float64))
+		if words := remaining["words"].([]interface{}); len(words) > 2 {
+			t.Errorf("Expected at most 2 words got %v", words)
+		}
+
+		undo := rpcResult(t, s, `{"jsonrpc":"2.0","id":5,"method":"undo","params":{"game":"1"}}`)
+		expectGotFloat(t, afterFirst, undo["remaining"].(float64))
+		expectGotFloat(t, 1, undo["turns"].(float64))
+		rpcResult(t, s, `{"jsonrpc":"2.0","id":6,"method":"undo","params":{"game":"1"}}`)
+		expectGotFloat(t, rpcInvalidParams, rpcErrorCode(t, s, `{"jsonrpc":"2.0","id":7,"method":"undo","params":{"game":"1"}}`))
+	})
+
+	t.Run("doesn't solve a game with lies on an all-hit score", func(t *testing.T) {
+		s := newRPCServer()
+		rpcResult(t, s, `{"jsonrpc":"2.0","id":1,"method":"newGame","params":{"lies":1}}`)
+		result := rpcResult(t, s, `{"jsonrpc":"2.0","id":2,"method":"guess","params":{"game":"1","guess":"crane","score":"HHHHH"}}`)
+		if result["solved"].(bool) || result["remaining"].(float64) == 0 {
+			t.Errorf("Expected an unsolved game with remaining words got %v", result)
+		}
+		rpcResult(t, s, `{"jsonrpc":"2.0","id":3,"method":"newGame"}`)
+		result = rpcResult(t, s, `{"jsonrpc":"2.0","id":4,"method":"guess","params":{"game":"2","guess":"crane","score":"HHHHH"}}`)
+		if !result["solved"].(bool) {
+			t.Errorf("Expected a solved game got %v", result)
+		}
+	})
+
+	t.Run("ranks guesses", func(t *testing.T) {
+		s := newRPCServer()
+		rpcResult(t, s, `{"jsonrpc":"2.0","id":1,"method":"newGame","params":{"strategy":"entropy"}}`)
+		rpcResult(t, s, `{"jsonrpc":"2.0","id":2,"method":"guess","params":{"game":"1","guess":"raise","score":"..h.."}}`)
+		rpcResult(t, s, `{"jsonrpc":"2.0","id":3,"method":"guess","params":{"game":"1","guess":"count","score":"hh..."}}`)
+		result := rpcResult(t, s, `{"jsonrpc":"2.0","id":4,"method":"suggestions","params":{"game":"1","count":3}}`)
+		if suggestions := result["suggestions"].([]interface{}); len(suggestions) != 3 {
+			t.Errorf("Expected 3 suggestions got %v", suggestions)
+		}
+	})
+
+	t.Run("shares the opening weights", func(t *testing.T) {
+		s := newRPCServer()
+		rpcResult(t, s, `{"jsonrpc":"2.0","id":1,"method":"newGame"}`)
+		rpcResult(t, s, `{"jsonrpc":"2.0","id":2,"method":"newGame"}`)
+		s.openings[openingKey(s.games["2"].settings)] = map[string]float64{"SLATE": 1, "CRANE": 2}
+		result := rpcResult(t, s, `{"jsonrpc":"2.0","id":3,"method":"suggestions","params":{"game":"1"}}`)
+		best := result["suggestions"].([]interface{})[0].(map[string]interface{})
+		expectGotString(t, "SLATE", best["guess"].(string))
+	})
+
+	t.Run("reports errors", func(t *testing.T) {
+		s := newRPCServer()
+		expectGotFloat(t, rpcParseError, rpcErrorCode(t, s, `{"jsonrpc":`))
+		expectGotFloat(t, rpcInvalidRequest, rpcErrorCode(t, s, `{"id":1,"method":"newGame"}`))
+		expectGotFloat(t, rpcMethodNotFound, rpcErrorCode(t, s, `{"jsonrpc":"2.0","id":1,"method":"solve"}`))
+		expectGotFloat(t, rpcInvalidParams, rpcErrorCode(t, s, `{"jsonrpc":"2.0","id":1,"method":"newGame","params":{"strategy":"luck"}}`))
+		expectGotFloat(t, rpcInvalidParams, rpcErrorCode(t, s, `{"jsonrpc":"2.0","id":1,"method":"remaining","params":{"game":"9"}}`))
+		rpcResult(t, s, `{"jsonrpc":"2.0","id":1,"method":"newGame"}`)
+		expectGotFloat(t, rpcInvalidParams, rpcErrorCode(t, s, `{"jsonrpc":"2.0","id":1,"method":"guess","params":{"game":"1","guess":"abc","score":"..."}}`))
+	})
+
+	t.Run("rejects invalid guesses and scores", func(t *testing.T) {
+		s := newRPCServer()
+		rpcResult(t, s, `{"jsonrpc":"2.0","id":1,"method":"newGame"}`)
+		for _, params := range []string{
+			`{"game":"1","guess":"xxxxx","score":"....."}`,
+			`{"game":"1","guess":"raise","score":"..x.."}`,
+			`{"game":"1","guess":"raise","score":"..Y.."}`,
+		} {
+			request := `{"jsonrpc":"2.0","id":2,"method":"guess","params":` + params + `}`
+			expectGotFloat(t, rpcInvalidParams, rpcErrorCode(t, s, request))
+		}
+		if len(s.games["1"].turns) != 0 {
+			t.Errorf("Expected no turn, got %v", s.games["1"].turns)
+		}
+		rpcResult(t, s, `{"jsonrpc":"2.0","id":3,"method":"guess","params":{"game":"1","guess":"aahed","score":"?...."}}`)
+	})
+
+	t.Run("rejects batches", func(t *testing.T) {
+		s := newRPCServer()
+		batch := `[{"jsonrpc":"2.0","id":1,"method":"newGame"}]`
+		expectGotFloat(t, rpcInvalidRequest, rpcErrorCode(t, s, batch))
+		if len(s.games) != 0 {
+			t.Errorf("Expected no game from a batch")
+		}
+	})
+
+	t.Run("doesn't answer notifications", func(t *testing.T) {
+		s := newRPCServer()
+		if response := s.handle([]byte(`{"jsonrpc":"2.0","method":"newGame"}`)); response != nil {
+			t.Errorf("Expected no response got %v", response)
+		}
+		if len(s.games) != 1 {
+			t.Errorf("Expected the notification to start a game")
+		}
+	})
+
+	t.Run("serves one response per line", func(t *testing.T) {
+		input := `{"jsonrpc":"2.0","id":"a","method":"newGame"}` + "\n\n" +
+			`{"jsonrpc":"2.0","id":"b","method":"remaining","params":{"game":"1","count":1}}`
+		var output bytes.Buffer
+		if err := newRPCServer().serve(bufio.NewReader(strings.NewReader(input)), &output); err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+		if len(lines) != 2 || !strings.HasPrefix(lines[1], `{"jsonrpc":"2.0","id":"b","result":`) {
+			t.Errorf("Expected two responses got %v", lines)
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"hash/fnv"
+	"os"
+)
+
+// dictionaryIdentity identifies the word lists a session was played with, so
+// a session isn't resumed with different lists.
+type dictionaryIdentity struct {
+	Name      string `json:"name"`
+	Words     int    `json:"words"`
+	Solutions int    `json:"solutions"`
+	Checksum  string `json:"checksum"`
+}
+
+func identifyDictionary(name string, words, solutions *[]string) dictionaryIdentity {
+	hash := fnv.New64a()
+	for _, list := range []*[]string{words, solutions} {
+		for _, word := range *list {
+			hash.Write([]byte(word))
+			hash.Write([]byte{'\n'})
+		}
+		hash.Write([]byte{0})
+	}
+	return dictionaryIdentity{name, len(*words), len(*solutions), fmt.Sprintf("%016x", hash.Sum64())}
+}
+
+// sessionTurn is either a guess with its score or a constraint input.
+type sessionTurn struct {
+	Guess       string `json:"guess,omitempty"`
+	Score       string `json:"score,omitempty"`
+	Constraints string `json:"constraints,omitempty"`
+}
+
+type sessionSettings struct {
+	Suggestions     int      `json:"suggestions"`
+	Strategy        string   `json:"strategy,omitempty"`
+	Lies            int      `json:"lies,omitempty"`
+	ExcludedAnswers []string `json:"excludedAnswers,omitempty"`
+	AddSolutions    bool     `json:"addSolutions,omitempty"`
+}
+
+type session struct {
+	Dictionary dictionaryIdentity `json:"dictionary"`
+	Settings   sessionSettings    `json:"settings"`
+	Turns      []sessionTurn      `json:"turns"`
+}
+
+func newSession(dictionary dictionaryIdentity, settings sessionSettings) *session {
+	return &session{Dictionary: dictionary, Settings: settings, Turns: []sessionTurn{}}
+}
+
+func loadSession(path string) (*session, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &session{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("Can't read session '%v': %v", path, err)
+	}
+	return s, nil
+}
+
+func (s *session) save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+func (s *session) recordGuess(guess, score string) {
+	s.Turns = append(s.Turns, sessionTurn{Guess: guess, Score: score})
+}
+
+func (s *session) recordConstraints(input string) {
+	s.Turns = append(s.Turns, sessionTurn{Constraints: input})
+}
+
+// applyFlags applies the flags given when resuming the session. Only the
+// number of suggestions and the strategy can change; the flags that change
+// the remaining words or where the game is saved are rejected.
+func (s *session) applyFlags(flags *flag.FlagSet) error {
+	var err error
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "suggestions":
+			s.Settings.Suggestions = f.Value.(flag.Getter).Get().(int)
+		case "strategy":
+			s.Settings.Strategy = f.Value.String()
+		case "lies", "answers", "add-solutions", "save":
+			if err == nil {
+				err = fmt.Errorf("Can't use -%v with -resume, the resumed game keeps its settings", f.Name)
+			}
+		}
+	})
+	return err
+}
+
+// validate checks that every guess and score of the session has the word
+// length of the game.
+func (s *session) validate(length int) error {
+	for idx, turn := range s.Turns {
+		if turn.Constraints != "" {
+			if _, err := parseConstraints(turn.Constraints); err != nil {
+				return fmt.Errorf("Can't replay turn %v: %v", idx+1, err)
+			}
+			continue
+		}
+		if len(turn.Guess) != length || len(turn.Score) != length {
+			return fmt.Errorf("Can't replay guess '%v' with score '%v' in turn %v, expected %v letters",
+				turn.Guess, turn.Score, idx+1, length)
+		}
+	}
+	return nil
+}
+
+// replay applies all turns of the session to the game, after checking that
+// the game uses the same word lists, even if they were renamed, and that all
+// turns are valid.
+func (s *session) replay(wg *WordGame, dictionary dictionaryIdentity) error {
+	if s.Dictionary.Checksum != dictionary.Checksum {
+		return fmt.Errorf("Can't resume session with dictionary '%v' (%v) using dictionary '%v' (%v)",
+			s.Dictionary.Name, s.Dictionary.Checksum, dictionary.Name, dictionary.Checksum)
+	}
+	if err := s.validate(wg.wordLength()); err != nil {
+		return err
+	}
+	for _, turn := range s.Turns {
+		if turn.Constraints != "" {
+			c, _ := parseConstraints(turn.Constraints)
+			wg.constrain(c)
+			continue
+		}
+		wg.guess(turn.Guess, turn.Score)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"path/filepath"
+	"testing"
+)
+
+func TestSession(t *testing.T) {
+	words := []string{"ABC", "ACB", "EAD"}
+	dictionary := identifyDictionary("test", &words, &words)
+
+	t.Run("save and resume", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "session.json")
+		sess := newSession(dictionary, sessionSettings{Suggestions: 3})
+		sess.recordConstraints("no E")
+		sess.recordGuess("AEF", "H..")
+		if err := sess.save(path); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		resumed, err := loadSession(path)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if resumed.Settings.Suggestions != 3 {
+			t.Errorf("Expected '3' suggestions got '%v'", resumed.Settings.Suggestions)
+		}
+		wordGame := createWordGame(&words, 3)
+		if err := resumed.replay(wordGame, dictionary); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		reference := []string{"ABC", "ACB"}
+		compareWordSlices(t, wordGame.remainingWords, &reference)
+	})
+
+	t.Run("with renamed dictionary", func(t *testing.T) {
+		sess := newSession(dictionary, sessionSettings{})
+		renamed := identifyDictionary("renamed", &words, &words)
+		if err := sess.replay(createWordGame(&words, 3), renamed); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("with different dictionary", func(t *testing.T) {
+		sess := newSession(dictionary, sessionSettings{})
+		other := identifyDictionary("test", &words, &[]string{"ABC"})
+		if err := sess.replay(createWordGame(&words, 3), other); err == nil {
+			t.Errorf("Expected an error for a different dictionary")
+		}
+	})
+
+	t.Run("with a turn of the wrong length", func(t *testing.T) {
+		sess := newSession(dictionary, sessionSettings{})
+		sess.recordGuess("AEF", "H..")
+		sess.recordGuess("AE", "H.")
+		wordGame := createWordGame(&words, 3)
+		if err := sess.replay(wordGame, dictionary); err == nil {
+			t.Errorf("Expected an error for a guess of the wrong length")
+		}
+		if len(*wordGame.remainingWords) != len(words) {
+			t.Errorf("Expected no turn to be replayed")
+		}
+	})
+}
+
+func TestSessionApplyFlags(t *testing.T) {
+	parse := func(args ...string) *flag.FlagSet {
+		flags := flag.NewFlagSet("play", flag.ContinueOnError)
+		flags.Int("suggestions", 12, "")
+		flags.String("strategy", defaultStrategy, "")
+		flags.Int("lies", 0, "")
+		flags.String("answers", "", "")
+		flags.String("save", "", "")
+		flags.Bool("add-solutions", false, "")
+		flags.String("resume", "", "")
+		flags.Parse(args)
+		return flags
+	}
+
+	t.Run("changes suggestions and strategy", func(t *testing.T) {
+		sess := newSession(dictionaryIdentity{}, sessionSettings{Suggestions: 3, Strategy: "minimax"})
+		if err := sess.applyFlags(parse("-resume", "game.json", "-suggestions", "5", "-strategy", "entropy")); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if sess.Settings.Suggestions != 5 || sess.Settings.Strategy != "entropy" {
+			t.Errorf("Expected 5 suggestions with entropy got %+v", sess.Settings)
+		}
+	})
+
+	t.Run("rejects settings of the game", func(t *testing.T) {
+		for _, args := range [][]string{{"-lies", "1"}, {"-answers", "answers.txt"}, {"-save", "other.json"}, {"-add-solutions"}} {
+			sess := newSession(dictionaryIdentity{}, sessionSettings{})
+			if err := sess.applyFlags(parse(append([]string{"-resume", "game.json"}, args...)...)); err == nil {
+				t.Errorf("Expected an error for %v", args)
+			}
+		}
+	})
+}
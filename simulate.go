@@ -0,0 +1,103 @@
+package main
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// maxSimulatedTurns stops simulated games that don't converge.
+const maxSimulatedTurns = 20
+
+type cachedChoice struct {
+	once  sync.Once
+	guess string
+}
+
+// simulator plays games against known answers. Its choices only depend on
+// the previous guesses and scores, so they are cached per game state and
+// shared between all games.
+type simulator struct {
+	allWords  *[]string
+	solutions *[]string
+	strategy  string
+	opener    string
+	mutex     sync.Mutex
+	choices   map[string]*cachedChoice
+}
+
+func newSimulator(allWords, solutions *[]string, strategy, opener string) *simulator {
+	return &simulator{
+		allWords:  allWords,
+		solutions: solutions,
+		strategy:  strategy,
+		opener:    strings.ToUpper(opener),
+		choices:   map[string]*cachedChoice{},
+	}
+}
+
+func (s *simulator) choose(state string, wg *WordGame) string {
+	s.mutex.Lock()
+	choice, ok := s.choices[state]
+	if !ok {
+		choice = &cachedChoice{}
+		s.choices[state] = choice
+	}
+	s.mutex.Unlock()
+	choice.once.Do(func() { choice.guess = wg.pickGuess() })
+	return choice.guess
+}
+
+// firstGuess returns the opener, or the guess the strategy chose as opener.
+func (s *simulator) firstGuess() string {
+	if s.opener != "" {
+		return s.opener
+	}
+	return s.choose("", &WordGame{allWords: s.allWords, remainingWords: s.solutions, strategy: s.strategy})
+}
+
+// play returns the guesses needed to find the answer, including the final
+// one, or maxSimulatedTurns+1 if the game didn't converge.
+func (s *simulator) play(answer string) *[]string {
+	wg := &WordGame{allWords: s.allWords, remainingWords: s.solutions, strategy: s.strategy}
+	guesses := []string{}
+	state := ""
+	for len(guesses) < maxSimulatedTurns {
+		guess := s.opener
+		if len(guesses) > 0 || guess == "" {
+			guess = s.choose(state, wg)
+		}
+		guesses = append(guesses, guess)
+		score := scoreAgainst(guess, answer)
+		if guess == answer {
+			return &guesses
+		}
+		wg.guess(guess, score)
+		state += guess + ":" + score + ","
+	}
+	guesses = append(guesses, "")
+	return &guesses
+}
+
+// playAll plays a game for every answer concurrently and returns the number
+// of guesses needed for each of them.
+func (s *simulator) playAll(answers *[]string) []int {
+	turns := make([]int, len(*answers))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for worker := 0; worker < runtime.NumCPU(); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				turns[idx] = len(*s.play((*answers)[idx]))
+			}
+		}()
+	}
+	for idx := range *answers {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+	return turns
+}
@@ -0,0 +1,31 @@
+package main
+
+import "testing"
+
+func TestSimulator(t *testing.T) {
+	guesses := []string{"AXY", "BXY", "CXY", "ABC", "XXX"}
+	solutions := []string{"AXY", "BXY", "CXY"}
+
+	t.Run("playing a game", func(t *testing.T) {
+		s := newSimulator(&guesses, &solutions, "minimax", "")
+		reference := []string{"ABC", "CXY"}
+		compareWordSlices(t, s.play("CXY"), &reference)
+	})
+
+	t.Run("with fixed opener", func(t *testing.T) {
+		s := newSimulator(&guesses, &solutions, "minimax", "xxx")
+		expectGotString(t, "XXX", s.firstGuess())
+		reference := []string{"XXX", "ABC", "AXY"}
+		compareWordSlices(t, s.play("AXY"), &reference)
+	})
+
+	t.Run("playing all answers", func(t *testing.T) {
+		s := newSimulator(&guesses, &solutions, "entropy", "")
+		turns := s.playAll(&solutions)
+		for idx, turn := range turns {
+			if turn != 2 {
+				t.Errorf("Expected 2 turns for '%v' got %v", solutions[idx], turn)
+			}
+		}
+	})
+}
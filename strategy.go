@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// weightFunc rates a guess by the distribution of its scores over the
+// remaining words. Guesses with lower weights are better.
+type weightFunc func(distribution map[string]int) float64
+
+// minimaxWeight is the number of remaining words in the worst case.
+func minimaxWeight(distribution map[string]int) float64 {
+	maxCount := 0
+	for _, count := range distribution {
+		if count > maxCount {
+			maxCount = count
+		}
+	}
+	return float64(maxCount)
+}
+
+// entropyWeight is the negative expected information of the guess in bits.
+func entropyWeight(distribution map[string]int) float64 {
+	return -entropy(distribution)
+}
+
+// expectedSizeWeight is the expected number of remaining words.
+func expectedSizeWeight(distribution map[string]int) float64 {
+	total, squares := 0, 0
+	for _, count := range distribution {
+		total += count
+		squares += count * count
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(squares) / float64(total)
+}
+
+var strategies = map[string]weightFunc{
+	"minimax":  minimaxWeight,
+	"entropy":  entropyWeight,
+	"expected": expectedSizeWeight,
+}
+
+const defaultStrategy = "minimax"
+
+func strategyNames() []string {
+	names := []string{}
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func checkStrategy(name string) error {
+	if _, ok := strategies[name]; !ok {
+		return fmt.Errorf("Unknown strategy '%v', expected one of %v", name, strings.Join(strategyNames(), ", "))
+	}
+	return nil
+}
+
+func (wg *WordGame) strategyName() string {
+	if wg.strategy == "" {
+		return defaultStrategy
+	}
+	return wg.strategy
+}
+
+func getKeysSortedByWeight(toSort *map[string]float64) *[]string {
+	keys := []string{}
+	for key := range *toSort {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := (*toSort)[keys[i]], (*toSort)[keys[j]]
+		if a != b {
+			return a < b
+		}
+		return keys[i] < keys[j]
+	})
+	return &keys
+}
+
+// pickGuess returns the guess to play next: the best ranked guess, preferring
+// remaining words among equally good guesses, as they might be the solution.
+func (wg *WordGame) pickGuess() string {
+	if len(*wg.remainingWords) == 1 {
+		return (*wg.remainingWords)[0]
+	}
+	weights := wg.getGuessWeights()
+	for _, word := range *wg.remainingWords {
+		if _, ok := weights[word]; !ok {
+			weights[word] = strategies[wg.strategyName()](wg.scoreDistribution(word))
+		}
+	}
+	best := (*getKeysSortedByWeight(&weights))[0]
+	for _, word := range *wg.remainingWords {
+		if weights[word] == weights[best] {
+			return word
+		}
+	}
+	return best
+}
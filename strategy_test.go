@@ -0,0 +1,59 @@
+package main
+
+import "testing"
+
+func TestWeights(t *testing.T) {
+	distribution := map[string]int{"...": 2, "H..": 1, "HHH": 1}
+
+	t.Run("minimax", func(t *testing.T) {
+		expectGotFloat(t, 2, minimaxWeight(distribution))
+	})
+
+	t.Run("entropy", func(t *testing.T) {
+		expectGotFloat(t, -1.5, entropyWeight(distribution))
+	})
+
+	t.Run("expected size", func(t *testing.T) {
+		expectGotFloat(t, 1.5, expectedSizeWeight(distribution))
+	})
+}
+
+func TestCheckStrategy(t *testing.T) {
+	t.Run("with known strategy", func(t *testing.T) {
+		if err := checkStrategy("entropy"); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("with unknown strategy", func(t *testing.T) {
+		if err := checkStrategy("random"); err == nil {
+			t.Errorf("Expected an error for an unknown strategy")
+		}
+	})
+}
+
+func TestGetKeysSortedByWeight(t *testing.T) {
+	t.Run("with non-unique values", func(t *testing.T) {
+		m := map[string]float64{"ghi": -1.5, "def": 0.5, "abc": 0.5}
+		reference := []string{"ghi", "abc", "def"}
+		compareWordSlices(t, getKeysSortedByWeight(&m), &reference)
+	})
+}
+
+func TestWordGamePickGuess(t *testing.T) {
+	t.Run("prefers remaining words", func(t *testing.T) {
+		wordGame := createWordGameFromWordLists(&[]string{"ABX"}, &[]string{"ABC", "ABD"})
+		expectGotString(t, "ABC", wordGame.pickGuess())
+	})
+
+	t.Run("with single remaining word", func(t *testing.T) {
+		wordGame := createWordGameFromWordLists(&[]string{"AXY", "BXY"}, &[]string{"XYZ"})
+		expectGotString(t, "XYZ", wordGame.pickGuess())
+	})
+
+	t.Run("with entropy strategy", func(t *testing.T) {
+		wordGame := createWordGame(&[]string{"AXY", "BXY", "CXY", "ABC", "XXX"}, 3)
+		wordGame.strategy = "entropy"
+		expectGotString(t, "ABC", (*wordGame.getBestGuesses())[0])
+	})
+}
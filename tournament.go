@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+type tournamentResult struct {
+	strategy string
+	opener   string
+	games    int
+	mean     float64
+	stddev   float64
+	failures int
+	worst    int
+	runtime  time.Duration
+}
+
+func summarizeTurns(turns []int, maxGuesses int) (mean, stddev float64, failures, worst int) {
+	for _, t := range turns {
+		mean += float64(t)
+		if t > maxGuesses {
+			failures += 1
+		}
+		if t > worst {
+			worst = t
+		}
+	}
+	mean /= float64(len(turns))
+	for _, t := range turns {
+		stddev += (float64(t) - mean) * (float64(t) - mean)
+	}
+	stddev = math.Sqrt(stddev / float64(len(turns)))
+	return mean, stddev, failures, worst
+}
+
+// runTournament simulates every combination of strategy and opener against
+// all answers. An empty opener lets the strategy choose its own.
+func runTournament(allWords, solutions, answers *[]string, strategyNames, openers []string, maxGuesses int) []tournamentResult {
+	results := []tournamentResult{}
+	for _, strategy := range strategyNames {
+		for _, opener := range openers {
+			start := time.Now()
+			s := newSimulator(allWords, solutions, strategy, opener)
+			r := tournamentResult{strategy: strategy, opener: s.firstGuess(), games: len(*answers)}
+			r.mean, r.stddev, r.failures, r.worst = summarizeTurns(s.playAll(answers), maxGuesses)
+			r.runtime = time.Since(start)
+			results = append(results, r)
+		}
+	}
+	return results
+}
+
+var tournamentHeader = []string{"Strategy", "Opener", "Games", "Mean", "StdDev", "Failures", "Worst", "Runtime"}
+
+func (r *tournamentResult) fields() []string {
+	return []string{
+		r.strategy,
+		r.opener,
+		fmt.Sprint(r.games),
+		fmt.Sprintf("%.4f", r.mean),
+		fmt.Sprintf("%.4f", r.stddev),
+		fmt.Sprint(r.failures),
+		fmt.Sprint(r.worst),
+		fmt.Sprintf("%.1fs", r.runtime.Seconds()),
+	}
+}
+
+func tournamentReport(results []tournamentResult) *report {
+	r := newReport("tournament", "strategy", "opener", "games", "mean", "stddev", "failures", "worst", "runtime_seconds")
+	for _, t := range results {
+		r.add(t.strategy, t.opener, t.games, t.mean, t.stddev, t.failures, t.worst, t.runtime.Seconds())
+	}
+	return r
+}
+
+func writeResultsText(w io.Writer, results []tournamentResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(tournamentHeader, "\t"))
+	for _, r := range results {
+		fmt.Fprintln(tw, strings.Join(r.fields(), "\t"))
+	}
+	return tw.Flush()
+}
+
+func writeResultsCSV(w io.Writer, results []tournamentResult) error {
+	cw := csv.NewWriter(w)
+	cw.Write(tournamentHeader)
+	for _, r := range results {
+		cw.Write(r.fields())
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeResultsMarkdown(w io.Writer, results []tournamentResult) error {
+	fmt.Fprintf(w, "| %v |\n", strings.Join(tournamentHeader, " | "))
+	fmt.Fprintf(w, "|%v\n", strings.Repeat(" --- |", len(tournamentHeader)))
+	for _, r := range results {
+		if _, err := fmt.Fprintf(w, "| %v |\n", strings.Join(r.fields(), " | ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeResultsFile(path string, results []tournamentResult, write func(io.Writer, []tournamentResult) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return write(file, results)
+}
+
+func sampleWords(words *[]string, size int, seed int64) *[]string {
+	if size <= 0 || size >= len(*words) {
+		return words
+	}
+	sample := []string{}
+	for _, idx := range rand.New(rand.NewSource(seed)).Perm(len(*words))[:size] {
+		sample = append(sample, (*words)[idx])
+	}
+	return &sample
+}
+
+// checkOpeners checks that every opener is one of the guesses. An empty
+// opener stands for the strategy's own choice.
+func checkOpeners(openers []string, guesses *[]string) error {
+	for _, opener := range openers {
+		if opener != "" && !containsWord(guesses, strings.ToUpper(opener)) {
+			return fmt.Errorf("Invalid opener '%v', it's not one of the guesses", opener)
+		}
+	}
+	return nil
+}
+
+func splitList(input string) []string {
+	items := []string{}
+	for _, item := range strings.Split(input, ",") {
+		items = append(items, strings.TrimSpace(item))
+	}
+	return items
+}
+
+func tournament(args []string) error {
+	flags := flag.NewFlagSet("tournament", flag.ExitOnError)
+	strategyList := flags.String("strategies", strings.Join(strategyNames(), ","), "comma separated strategies")
+	openerList := flags.String("openers", "", "comma separated openers, empty for the strategy's own choice")
+	sample := flags.Int("sample", 0, "number of random answers to play, 0 for all")
+	seed := flags.Int64("seed", 1, "seed for the random sample")
+	maxGuesses := flags.Int("max-guesses", 6, "games needing more guesses are failures")
+	csvPath := flags.String("csv", "", "export the results as CSV to `FILE`")
+	markdownPath := flags.String("markdown", "", "export the results as Markdown to `FILE`")
+	flags.Parse(args)
+
+	strategyNames := splitList(*strategyList)
+	for _, name := range strategyNames {
+		if err := checkStrategy(name); err != nil {
+			return err
+		}
+	}
+	solutions := applyToWordSlice(strings.ToUpper, &possibleSolutions)
+	guesses := defaultGuesses()
+	answers := sampleWords(solutions, *sample, *seed)
+	openers := splitList(*openerList)
+	if err := checkOpeners(openers, guesses); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(messages, "Play %v games per contestant ...\n", len(*answers))
+	results := runTournament(guesses, solutions, answers, strategyNames, openers, *maxGuesses)
+	if machineOutput() {
+		if err := tournamentReport(results).emit(); err != nil {
+			return err
+		}
+	} else if err := writeResultsText(messages, results); err != nil {
+		return err
+	}
+	if *csvPath != "" {
+		if err := writeResultsFile(*csvPath, results, writeResultsCSV); err != nil {
+			return err
+		}
+	}
+	if *markdownPath != "" {
+		return writeResultsFile(*markdownPath, results, writeResultsMarkdown)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSummarizeTurns(t *testing.T) {
+	t.Run("with failures", func(t *testing.T) {
+		mean, stddev, failures, worst := summarizeTurns([]int{2, 4, 4, 4, 5, 5, 7, 9}, 6)
+		expectGotFloat(t, 5, mean)
+		expectGotFloat(t, 2, stddev)
+		if failures != 2 || worst != 9 {
+			t.Errorf("Expected 2 failures and worst 9, got %v and %v", failures, worst)
+		}
+	})
+}
+
+func TestRunTournament(t *testing.T) {
+	guesses := []string{"AXY", "BXY", "CXY", "ABC", "XXX"}
+	solutions := []string{"AXY", "BXY", "CXY"}
+	results := runTournament(&guesses, &solutions, &solutions, []string{"minimax", "entropy"}, []string{"", "XXX"}, 2)
+
+	t.Run("every contestant plays", func(t *testing.T) {
+		if len(results) != 4 {
+			t.Fatalf("Expected 4 results got %v", len(results))
+		}
+		expectGotString(t, "ABC", results[0].opener)
+		expectGotFloat(t, 2, results[0].mean)
+		if results[1].failures != 3 {
+			t.Errorf("Expected 3 failures with opener XXX, got %v", results[1].failures)
+		}
+	})
+
+	t.Run("exported as CSV and Markdown", func(t *testing.T) {
+		var csv, markdown bytes.Buffer
+		writeResultsCSV(&csv, results)
+		writeResultsMarkdown(&markdown, results)
+		if !strings.HasPrefix(csv.String(), "Strategy,Opener,Games,Mean") {
+			t.Errorf("Unexpected CSV '%v'", csv.String())
+		}
+		if !strings.Contains(markdown.String(), "| minimax | ABC | 3 | 2.0000 |") {
+			t.Errorf("Unexpected Markdown '%v'", markdown.String())
+		}
+	})
+}
+
+func TestSampleWords(t *testing.T) {
+	words := []string{"A", "B", "C", "D", "E"}
+
+	t.Run("with same seed", func(t *testing.T) {
+		compareWordSlices(t, sampleWords(&words, 3, 7), sampleWords(&words, 3, 7))
+	})
+
+	t.Run("with sample larger than the list", func(t *testing.T) {
+		compareWordSlices(t, sampleWords(&words, 10, 7), &words)
+	})
+}
+
+func TestCheckOpeners(t *testing.T) {
+	guesses := []string{"CRANE", "RAISE", "SLATE"}
+
+	t.Run("with valid openers", func(t *testing.T) {
+		if err := checkOpeners([]string{"", "raise", "SLATE"}, &guesses); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("with wrong length", func(t *testing.T) {
+		if err := checkOpeners([]string{"raise", "xx"}, &guesses); err == nil {
+			t.Errorf("Expected an error for an opener of the wrong length")
+		}
+	})
+
+	t.Run("with unknown word", func(t *testing.T) {
+		if err := checkOpeners([]string{"raise", "xxxxx"}, &guesses); err == nil {
+			t.Errorf("Expected an error for an opener that isn't a guess")
+		}
+	})
+}
@@ -0,0 +1,339 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+type tuiRow struct {
+	guess []rune
+	score []rune
+}
+
+func (r *tuiRow) complete(length int) bool {
+	return len(r.guess) == length
+}
+
+// tui is a full-screen front-end for a WordGame. Every complete row is
+// replayed into a fresh game whenever a row changes, so previous rows can be
+// edited at any time.
+type tui struct {
+	length      int
+	rows        []tuiRow
+	row, col    int
+	wg          *WordGame
+	suggestions *[]string
+	generation  int
+	scroll      int
+	width       int
+	height      int
+	message     string
+	cancel      context.CancelFunc
+}
+
+type suggestionResult struct {
+	generation  int
+	suggestions *[]string
+}
+
+func newTUI(length int) *tui {
+	t := &tui{length: length, width: 80, height: 24}
+	t.rows = []tuiRow{{score: []rune(strings.Repeat(".", length))}}
+	t.replay()
+	return t
+}
+
+// replay rebuilds the game from all complete rows and invalidates the
+// current suggestions.
+func (t *tui) replay() {
+	t.wg = createDefaultWordGame()
+	t.message = ""
+	for _, r := range t.rows {
+		if !r.complete(t.length) {
+			continue
+		}
+		if string(r.score) == strings.Repeat("H", t.length) {
+			t.message = fmt.Sprintf("Solved: %v", string(r.guess))
+		}
+		t.wg.guess(string(r.guess), string(r.score))
+	}
+	if len(*t.wg.remainingWords) == 0 {
+		t.message = "No solution found :-("
+	}
+	t.suggestions = nil
+	t.generation += 1
+	t.scroll = 0
+}
+
+// calculateSuggestions ranks the guesses in the background and cancels the
+// previous ranking, whose result would be outdated anyway. The result is
+// dropped once the context is done.
+func (t *tui) calculateSuggestions(ctx context.Context, results chan<- suggestionResult) {
+	if t.cancel != nil {
+		t.cancel()
+	}
+	ctx, t.cancel = context.WithCancel(ctx)
+	generation, wg := t.generation, t.wg
+	go func() {
+		suggestions := wg.getBestGuessesContext(ctx)
+		select {
+		case results <- suggestionResult{generation, suggestions}:
+		case <-ctx.Done():
+		}
+	}()
+}
+
+func cycleScore(score rune) rune {
+	switch score {
+	case '.':
+		return 'h'
+	case 'h':
+		return 'H'
+	}
+	return '.'
+}
+
+// handleKey applies a key to the state and reports whether the game has to
+// be replayed and whether the front-end should quit.
+func (t *tui) handleKey(key string) (replay bool, quit bool) {
+	r := &t.rows[t.row]
+	switch {
+	case key == "ctrl-c" || key == "esc":
+		return false, true
+	case key == "left":
+		if t.col > 0 {
+			t.col -= 1
+		}
+	case key == "right" || key == "tab":
+		if t.col < t.length-1 {
+			t.col += 1
+		}
+	case key == "up":
+		if t.row > 0 {
+			t.row -= 1
+		}
+	case key == "down":
+		if t.row < len(t.rows)-1 {
+			t.row += 1
+		}
+	case key == "pgup":
+		t.scroll -= t.height / 2
+		if t.scroll < 0 {
+			t.scroll = 0
+		}
+	case key == "pgdn":
+		if t.scroll+t.height/2 < len(*t.wg.remainingWords) {
+			t.scroll += t.height / 2
+		}
+	case key == "space":
+		r.score[t.col] = cycleScore(r.score[t.col])
+		return r.complete(t.length), false
+	case key == "backspace":
+		if len(r.guess) == 0 {
+			return false, false
+		}
+		wasComplete := r.complete(t.length)
+		r.guess = r.guess[:len(r.guess)-1]
+		t.col = len(r.guess)
+		return wasComplete, false
+	case key == "enter":
+		if !r.complete(t.length) {
+			t.message = fmt.Sprintf("Invalid length guess '%v'", string(r.guess))
+			return false, false
+		}
+		if t.row == len(t.rows)-1 {
+			t.rows = append(t.rows, tuiRow{score: []rune(strings.Repeat(".", t.length))})
+		}
+		t.row, t.col = t.row+1, 0
+	case strings.HasPrefix(key, "click "):
+		var x, y int
+		fmt.Sscanf(key, "click %d %d", &x, &y)
+		row, col := y-2, (x-2)/4
+		if row >= 0 && row < len(t.rows) && x >= 2 && col < t.length {
+			t.row, t.col = row, col
+			return t.handleKey("space")
+		}
+	case len(key) == 1 && key[0] >= 'a' && key[0] <= 'z':
+		if r.complete(t.length) {
+			return false, false
+		}
+		r.guess = append(r.guess, rune(strings.ToUpper(key)[0]))
+		t.col = len(r.guess)
+		if t.col == t.length {
+			t.col -= 1
+		}
+		return r.complete(t.length), false
+	}
+	return false, false
+}
+
+func tileColor(score rune) string {
+	switch score {
+	case 'H':
+		return "\033[30;42m"
+	case 'h':
+		return "\033[30;43m"
+	}
+	return "\033[97;100m"
+}
+
+func moveTo(w io.Writer, line, column int) {
+	fmt.Fprintf(w, "\033[%d;%dH\033[K", line+1, column+1)
+}
+
+func (t *tui) render(w io.Writer) {
+	var b bytes.Buffer
+	b.WriteString("\033[H\033[2J")
+	moveTo(&b, 0, 0)
+	fmt.Fprintf(&b, "Wordle Solver: %v remaining words", len(*t.wg.remainingWords))
+
+	for idx, r := range t.rows {
+		moveTo(&b, 2+idx, 2)
+		for col := 0; col < t.length; col++ {
+			letter := " "
+			if col < len(r.guess) {
+				letter = string(r.guess[col])
+			}
+			left, right := " ", " "
+			if idx == t.row && col == t.col {
+				left, right = "[", "]"
+			}
+			fmt.Fprintf(&b, "%v%v%v%v\033[0m ", tileColor(r.score[col]), left, letter, right)
+		}
+	}
+
+	line := 3 + len(t.rows)
+	moveTo(&b, line, 0)
+	if t.suggestions == nil {
+		b.WriteString("Calculate best guesses ...")
+	} else {
+		suggestions := *t.suggestions
+		if len(suggestions) > 8 {
+			suggestions = suggestions[:8]
+		}
+		fmt.Fprintf(&b, "Best guesses: %v", strings.Join(suggestions, " "))
+	}
+	moveTo(&b, line+1, 0)
+	b.WriteString(t.message)
+	help := []string{
+		"a-z type, backspace delete, enter next row",
+		"left/right select tile, space or click cycle color",
+		"up/down select row, pgup/pgdn scroll words, esc quit",
+	}
+	for idx, text := range help {
+		moveTo(&b, line+3+idx, 0)
+		b.WriteString(text)
+	}
+
+	column := 4 * (t.length + 5)
+	if column+t.length < t.width {
+		moveTo(&b, 0, column)
+		fmt.Fprintf(&b, "Remaining words (%v-%v)", t.scroll+1, len(*t.wg.remainingWords))
+		for idx := 0; idx < t.height-3 && t.scroll+idx < len(*t.wg.remainingWords); idx++ {
+			moveTo(&b, 2+idx, column)
+			b.WriteString((*t.wg.remainingWords)[t.scroll+idx])
+		}
+	}
+	moveTo(&b, t.height-1, 0)
+	w.Write(b.Bytes())
+}
+
+// parseKeys translates raw terminal input into key names.
+func parseKeys(data []byte) []string {
+	keys := []string{}
+	for len(data) > 0 {
+		switch {
+		case bytes.HasPrefix(data, []byte("\033[M")) && len(data) >= 6:
+			if data[3]&3 == 0 {
+				keys = append(keys, fmt.Sprintf("click %d %d", int(data[4])-33, int(data[5])-33))
+			}
+			data = data[6:]
+			continue
+		case bytes.HasPrefix(data, []byte("\033[5~")):
+			keys, data = append(keys, "pgup"), data[4:]
+			continue
+		case bytes.HasPrefix(data, []byte("\033[6~")):
+			keys, data = append(keys, "pgdn"), data[4:]
+			continue
+		case bytes.HasPrefix(data, []byte("\033[")) && len(data) >= 3:
+			arrows := map[byte]string{'A': "up", 'B': "down", 'C': "right", 'D': "left"}
+			if key, ok := arrows[data[2]]; ok {
+				keys = append(keys, key)
+			}
+			data = data[3:]
+			continue
+		}
+		names := map[byte]string{3: "ctrl-c", 8: "backspace", 9: "tab", 13: "enter", 27: "esc", 32: "space", 127: "backspace"}
+		if name, ok := names[data[0]]; ok {
+			keys = append(keys, name)
+		} else if data[0] >= 'a' && data[0] <= 'z' || data[0] >= 'A' && data[0] <= 'Z' {
+			keys = append(keys, strings.ToLower(string(data[0])))
+		}
+		data = data[1:]
+	}
+	return keys
+}
+
+func runTUI(args []string) error {
+	flags := flag.NewFlagSet("tui", flag.ExitOnError)
+	flags.Parse(args)
+	if machineOutput() {
+		return fmt.Errorf("Can't run the TUI with output format '%v'", outputFormat)
+	}
+
+	restore, err := enableRawMode()
+	if err != nil {
+		return err
+	}
+	defer restore()
+	fmt.Fprint(messages, "\033[?1049h\033[?1000h\033[?25l")
+	defer fmt.Fprint(messages, "\033[?25h\033[?1000l\033[?1049l")
+
+	t := newTUI(dictionaryWordLength)
+	t.width, t.height = terminalSize()
+	keys := make(chan []byte)
+	go func() {
+		buffer := make([]byte, 64)
+		for {
+			n, err := os.Stdin.Read(buffer)
+			if err != nil {
+				close(keys)
+				return
+			}
+			keys <- append([]byte{}, buffer[:n]...)
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results := make(chan suggestionResult)
+	t.calculateSuggestions(ctx, results)
+	for {
+		t.render(messages)
+		select {
+		case result := <-results:
+			if result.generation == t.generation {
+				t.suggestions = result.suggestions
+			}
+		case data, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			for _, key := range parseKeys(data) {
+				replay, quit := t.handleKey(key)
+				if quit {
+					return nil
+				}
+				if replay {
+					t.replay()
+					t.calculateSuggestions(ctx, results)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseKeys(t *testing.T) {
+	t.Run("with letters and control keys", func(t *testing.T) {
+		got := parseKeys([]byte("aB \r\x7f\x1b"))
+		reference := []string{"a", "b", "space", "enter", "backspace", "esc"}
+		compareWordSlices(t, &got, &reference)
+	})
+
+	t.Run("with escape sequences", func(t *testing.T) {
+		got := parseKeys([]byte("\x1b[A\x1b[D\x1b[5~\x1b[M #$"))
+		reference := []string{"up", "left", "pgup", "click 2 3"}
+		compareWordSlices(t, &got, &reference)
+	})
+}
+
+func typeKeys(t *tui, keys ...string) {
+	for _, key := range keys {
+		if replay, _ := t.handleKey(key); replay {
+			t.replay()
+		}
+	}
+}
+
+func TestTUI(t *testing.T) {
+	t.Run("typing and scoring a row", func(t *testing.T) {
+		ui := newTUI(5)
+		typeKeys(ui, "c", "l", "i", "n", "e", "space", "space", "left", "space")
+		expectGotString(t, "CLINE", string(ui.rows[0].guess))
+		expectGotString(t, "...hH", string(ui.rows[0].score))
+		for _, word := range *ui.wg.remainingWords {
+			if word[4] != 'E' || word[3] == 'N' || !strings.ContainsRune(word, 'N') {
+				t.Errorf("Word '%v' doesn't match the score", word)
+			}
+		}
+	})
+
+	t.Run("editing a previous row", func(t *testing.T) {
+		ui := newTUI(5)
+		typeKeys(ui, "c", "l", "i", "n", "e", "enter", "a", "e", "s", "i", "r", "up", "space")
+		before := len(*ui.wg.remainingWords)
+		typeKeys(ui, "backspace", "t")
+		expectGotString(t, "CLINT", string(ui.rows[0].guess))
+		if len(*ui.wg.remainingWords) == before {
+			t.Errorf("Expected the remaining words to change")
+		}
+	})
+
+	t.Run("clicking a tile", func(t *testing.T) {
+		ui := newTUI(5)
+		typeKeys(ui, "c", "l", "i", "n", "e", "click 6 2", "click 6 2")
+		expectGotString(t, ".H...", string(ui.rows[0].score))
+	})
+
+	t.Run("rendering the state", func(t *testing.T) {
+		ui := newTUI(5)
+		typeKeys(ui, "c", "l", "i", "n", "e")
+		var buffer bytes.Buffer
+		ui.render(&buffer)
+		if !strings.Contains(buffer.String(), "Calculate best guesses ...") {
+			t.Errorf("Expected pending suggestions in '%v'", buffer.String())
+		}
+	})
+}
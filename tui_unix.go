@@ -0,0 +1,41 @@
+//go:build !windows
+// +build !windows
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	output, err := cmd.Output()
+	return strings.TrimSpace(string(output)), err
+}
+
+func enableRawMode() (func(), error) {
+	state, err := stty("-g")
+	if err != nil {
+		return nil, fmt.Errorf("Can't read terminal state: %v", err)
+	}
+	if _, err := stty("raw", "-echo"); err != nil {
+		return nil, fmt.Errorf("Can't switch terminal to raw mode: %v", err)
+	}
+	return func() { stty(state) }, nil
+}
+
+func terminalSize() (int, int) {
+	size, err := stty("size")
+	if err != nil {
+		return 80, 24
+	}
+	var height, width int
+	if _, err := fmt.Sscanf(size, "%d %d", &height, &width); err != nil || width == 0 || height == 0 {
+		return 80, 24
+	}
+	return width, height
+}
@@ -0,0 +1,14 @@
+//go:build windows
+// +build windows
+
+package main
+
+import "fmt"
+
+func enableRawMode() (func(), error) {
+	return nil, fmt.Errorf("The full-screen front-end is not supported on Windows")
+}
+
+func terminalSize() (int, int) {
+	return 80, 24
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// unknownTile marks a position of a score whose color isn't known.
+const unknownTile = '?'
+
+// expandUnknown returns all scores with the unknown positions replaced by
+// every color.
+func expandUnknown(score string) *[]string {
+	scores := []string{score}
+	for idx := 0; idx < len(score); idx++ {
+		if score[idx] != unknownTile {
+			continue
+		}
+		expanded := []string{}
+		for _, s := range scores {
+			for _, color := range scoreColors {
+				expanded = append(expanded, s[:idx]+string(color)+s[idx+1:])
+			}
+		}
+		scores = expanded
+	}
+	return &scores
+}
+
+// possibilities returns how many of the remaining words every possible
+// value of the unknown positions of the score would leave.
+func (wg *WordGame) possibilities(guess, score string) map[string]int {
+	counts := map[string]int{}
+	for _, possibility := range *expandUnknown(score) {
+		counts[possibility] = 0
+		for _, word := range *wg.remainingWords {
+			if scoreMatches(wg.score(guess, word), possibility, wg.lies) {
+				counts[possibility] += 1
+			}
+		}
+	}
+	return counts
+}
+
+func hasUnknownTiles(score string) bool {
+	return strings.ContainsRune(score, unknownTile)
+}
+
+func possibilitiesReport(counts map[string]int) *report {
+	scores := []string{}
+	for score := range counts {
+		scores = append(scores, score)
+	}
+	sort.Strings(scores)
+	r := newReport("possibilities", "score", "remaining")
+	for _, score := range scores {
+		r.add(score, counts[score])
+	}
+	return r
+}
+
+func printPossibilities(counts map[string]int) {
+	scores := []string{}
+	for score := range counts {
+		scores = append(scores, score)
+	}
+	sort.Strings(scores)
+	fmt.Fprintln(messages, "Remaining words for every possible score:")
+	for _, score := range scores {
+		fmt.Fprintf(messages, "  %v %5v\n", score, counts[score])
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import "testing"
+
+func TestExpandUnknown(t *testing.T) {
+	t.Run("with one unknown tile", func(t *testing.T) {
+		reference := []string{"H.", "Hh", "HH"}
+		compareWordSlices(t, expandUnknown("H?"), &reference)
+	})
+
+	t.Run("with two unknown tiles", func(t *testing.T) {
+		if got := len(*expandUnknown("??.")); got != 9 {
+			t.Errorf("Expected 9 scores got %v", got)
+		}
+	})
+}
+
+func TestWordGameGuessWithUnknownTiles(t *testing.T) {
+	words := []string{"ABC", "ACB", "EAD", "AEF"}
+
+	t.Run("keeps words compatible with any color", func(t *testing.T) {
+		wordGame := createWordGame(&words, 3)
+		wordGame.guess("AEF", "H?.")
+		reference := []string{"ABC", "ACB"}
+		compareWordSlices(t, wordGame.remainingWords, &reference)
+	})
+
+	t.Run("with lies", func(t *testing.T) {
+		wordGame := createWordGame(&words, 3)
+		wordGame.lies = 1
+		wordGame.guess("AEF", "HH?")
+		reference := []string{"ABC", "ACB", "AEF"}
+		compareWordSlices(t, wordGame.remainingWords, &reference)
+	})
+
+	t.Run("counts the words for every possibility", func(t *testing.T) {
+		wordGame := createWordGame(&words, 3)
+		counts := wordGame.possibilities("AEF", "?..")
+		if len(counts) != 3 || counts["H.."] != 2 || counts["h.."] != 0 || counts["..."] != 0 {
+			t.Errorf("Unexpected counts %v", counts)
+		}
+	})
+
+	t.Run("with the game's scorer", func(t *testing.T) {
+		wordGame := createWordGame(&[]string{"ABC", "AXB"}, 3)
+		wordGame.scorer = duplicateScorer{wordleScorer{length: 3}}
+		counts := wordGame.possibilities("AAX", "H?.")
+		if counts["H.."] != 1 || counts["Hh."] != 0 {
+			t.Errorf("Expected ABC to repeat A only once got %v", counts)
+		}
+	})
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// In Xordle two hidden words share no letters, and every guess is scored
+// against both of them at once: a tile is green if it is green for either
+// word, yellow if it is yellow for either word, and grey otherwise.
+
+type wordPair struct {
+	first, second int
+}
+
+// XordleGame is the WordGame of Xordle. Its candidates are pairs of
+// solutions, given by their indices.
+type XordleGame struct {
+	solutions      *[]string
+	remainingPairs []wordPair
+	// codeTable combines the encoded scores of the two words
+	codeTable [][]uint16
+	// ranking rates the guesses by the scores of the remaining pairs
+	ranking *WordGame
+}
+
+// letterMask returns the letters of a word of the letters A to Z as bits.
+func letterMask(word string) uint32 {
+	mask := uint32(0)
+	for _, letter := range word {
+		mask |= 1 << uint(letter-'A')
+	}
+	return mask
+}
+
+// disjointPairs returns all pairs of words without a common letter, each
+// pair only once.
+func disjointPairs(words *[]string) []wordPair {
+	masks := make([]uint32, len(*words))
+	for idx, word := range *words {
+		masks[idx] = letterMask(word)
+	}
+	pairs := []wordPair{}
+	for i := range masks {
+		for j := i + 1; j < len(masks); j++ {
+			if masks[i]&masks[j] == 0 {
+				pairs = append(pairs, wordPair{i, j})
+			}
+		}
+	}
+	return pairs
+}
+
+func createXordleGame(allWords, solutions *[]string) *XordleGame {
+	solutions = applyToWordSlice(strings.ToUpper, solutions)
+	xg := &XordleGame{
+		solutions:      solutions,
+		remainingPairs: disjointPairs(solutions),
+	}
+	xg.codeTable = combinedCodes(xg.wordLength())
+	xg.ranking = &WordGame{
+		allWords:       applyToWordSlice(strings.ToUpper, allWords),
+		remainingWords: solutions,
+		distribution:   xg.scoreDistribution,
+	}
+	return xg
+}
+
+// wordLength returns the length of the words of the pairs.
+func (xg *XordleGame) wordLength() int {
+	if len(*xg.solutions) == 0 {
+		return 0
+	}
+	return len((*xg.solutions)[0])
+}
+
+// combineScores scores a guess against both hidden words.
+func combineScores(first, second string) string {
+	rank := map[byte]int{'.': 0, 'h': 1, 'H': 2}
+	combined := []byte(first)
+	for idx := 0; idx < len(second); idx++ {
+		if rank[second[idx]] > rank[combined[idx]] {
+			combined[idx] = second[idx]
+		}
+	}
+	return string(combined)
+}
+
+func decodeScore(code, length int) string {
+	score := make([]byte, length)
+	for idx := length - 1; idx >= 0; idx-- {
+		score[idx] = scoreColors[code%3]
+		code /= 3
+	}
+	return string(score)
+}
+
+// combinedCodes combines the encoded scores of words of the given length, so
+// pairs can be scored by a lookup. The colors are encoded in the order of
+// their rank, so every tile of the combined code is the larger digit.
+func combinedCodes(length int) [][]uint16 {
+	codes := countScoreCodes(length)
+	table := make([][]uint16, codes)
+	for a := range table {
+		table[a] = make([]uint16, codes)
+		for b := range table[a] {
+			combined, unit := 0, 1
+			for x, y := a, b; unit < codes; x, y = x/3, y/3 {
+				digit := x % 3
+				if y%3 > digit {
+					digit = y % 3
+				}
+				combined += digit * unit
+				unit *= 3
+			}
+			table[a][b] = uint16(combined)
+		}
+	}
+	return table
+}
+
+func (xg *XordleGame) scorePair(guess string, pair wordPair) string {
+	return combineScores(scoreAgainst(guess, (*xg.solutions)[pair.first]), scoreAgainst(guess, (*xg.solutions)[pair.second]))
+}
+
+func (xg *XordleGame) scoreDistribution(guess string) map[string]int {
+	codes := make([]uint16, len(*xg.solutions))
+	for idx, solution := range *xg.solutions {
+		codes[idx] = uint16(encodeScore(scoreAgainst(guess, solution)))
+	}
+	counts := make([]int, len(xg.codeTable))
+	for _, pair := range xg.remainingPairs {
+		counts[xg.codeTable[codes[pair.first]][codes[pair.second]]] += 1
+	}
+	distribution := map[string]int{}
+	for code, count := range counts {
+		if count > 0 {
+			distribution[decodeScore(code, len(guess))] = count
+		}
+	}
+	return distribution
+}
+
+func (xg *XordleGame) getGuessWeights() map[string]float64 {
+	return xg.ranking.getGuessWeights()
+}
+
+func (xg *XordleGame) getBestGuesses() *[]string {
+	return xg.ranking.getBestGuesses()
+}
+
+// guess keeps the pairs matching the score, unknown tiles match any color.
+func (xg *XordleGame) guess(guess, score string) {
+	remainingPairs := []wordPair{}
+	for _, pair := range xg.remainingPairs {
+		if scoreMatches(xg.scorePair(guess, pair), score, 0) {
+			remainingPairs = append(remainingPairs, pair)
+		}
+	}
+	xg.remainingPairs = remainingPairs
+}
+
+func (xg *XordleGame) pairWords(pair wordPair) string {
+	return (*xg.solutions)[pair.first] + "+" + (*xg.solutions)[pair.second]
+}
+
+// remainingWords returns the remaining pairs as words like "ABBEY+CRUST".
+func (xg *XordleGame) remainingWords() *[]string {
+	words := []string{}
+	for _, pair := range xg.remainingPairs {
+		words = append(words, xg.pairWords(pair))
+	}
+	return &words
+}
+
+func readWord(prompt string, length int, normalize wordFunc) string {
+	word := ""
+	for len(word) != length {
+		word = readLine(prompt)
+		if len(word) != length {
+			fmt.Fprintf(messages, "Invalid length input '%v'\n", word)
+		}
+		word = normalize(word)
+	}
+	return word
+}
+
+func xordle(args []string) error {
+	flags := flag.NewFlagSet("xordle", flag.ExitOnError)
+	suggestions := flags.Int("suggestions", 12, "number of best guesses to show")
+	strategy := flags.String("strategy", defaultStrategy, "ranking strategy: "+strings.Join(strategyNames(), ", "))
+	flags.Parse(args)
+	if err := checkStrategy(*strategy); err != nil {
+		return err
+	}
+
+	if dictionaryWordLength > maxEncodedLength {
+		return fmt.Errorf("Can't play Xordle with words of %v letters, at most %v", dictionaryWordLength, maxEncodedLength)
+	}
+	xg := createXordleGame(defaultGuesses(), &possibleSolutions)
+	xg.ranking.strategy = *strategy
+	if isTerminal(messages) {
+		xg.ranking.progress = newProgressBar(messages).update
+	}
+	length := xg.wordLength()
+	fmt.Fprintf(messages, "Calculate best guesses for %v pairs ...\n", len(xg.remainingPairs))
+	for len(xg.remainingPairs) > 1 {
+		weights := xg.getGuessWeights()
+		bestGuesses := *getKeysSortedByWeight(&weights)
+		if len(bestGuesses) > *suggestions {
+			bestGuesses = bestGuesses[:*suggestions]
+		}
+		fmt.Fprintf(messages, "Best guesses: %v\n", bestGuesses)
+		if err := suggestionsReport(bestGuesses, weights).emit(); err != nil {
+			return err
+		}
+		guess := readWord("Your guess: ", length, strings.ToUpper)
+		score := readWord("Score of the guess: ", length, toUniqueScore)
+		xg.guess(guess, score)
+		fmt.Fprintf(messages, "Remaining pairs: %v\n", len(xg.remainingPairs))
+		if err := remainingReport(xg.remainingWords()).emit(); err != nil {
+			return err
+		}
+	}
+	if len(xg.remainingPairs) == 1 {
+		fmt.Fprintf(messages, "The solution is: %v\n", xg.pairWords(xg.remainingPairs[0]))
+	} else {
+		fmt.Fprintln(messages, "No solution found :-(")
+	}
+	if err := solutionReport(xg.remainingWords()).emit(); err != nil {
+		return err
+	}
+	readLine("")
+	return nil
+}
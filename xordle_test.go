@@ -0,0 +1,81 @@
+package main
+
+import "testing"
+
+func TestDisjointPairs(t *testing.T) {
+	t.Run("without common letters", func(t *testing.T) {
+		words := []string{"ABC", "DEF", "CDG", "XYZ"}
+		pairs := disjointPairs(&words)
+		reference := []wordPair{{0, 1}, {0, 3}, {1, 3}, {2, 3}}
+		if len(pairs) != len(reference) {
+			t.Fatalf("Expected %v got %v", reference, pairs)
+		}
+		for idx, pair := range pairs {
+			if pair != reference[idx] {
+				t.Errorf("Expected %v got %v", reference[idx], pair)
+			}
+		}
+	})
+}
+
+func TestCombineScores(t *testing.T) {
+	t.Run("takes the best color per tile", func(t *testing.T) {
+		expectGotString(t, "Hh.H", combineScores("H..h", ".h.H"))
+	})
+
+	t.Run("matches the lookup table", func(t *testing.T) {
+		code := combinedCodes(5)[encodeScore("H.h..")][encodeScore(".hH.h")]
+		expectGotString(t, "HhH.h", decodeScore(int(code), 5))
+	})
+
+	t.Run("matches the lookup table of other lengths", func(t *testing.T) {
+		code := combinedCodes(6)[encodeScore("H.h..h")][encodeScore(".hH.hH")]
+		expectGotString(t, "HhH.hH", decodeScore(int(code), 6))
+		code = combinedCodes(3)[encodeScore("h.H")][encodeScore("..h")]
+		expectGotString(t, "h.H", decodeScore(int(code), 3))
+	})
+}
+
+func TestXordleGame(t *testing.T) {
+	solutions := []string{"abc", "def", "xyz", "adx"}
+	guesses := []string{"AEZ", "BEY"}
+
+	t.Run("scores a guess against both words", func(t *testing.T) {
+		xg := createXordleGame(&guesses, &solutions)
+		expectGotString(t, "HH.", xg.scorePair("AEZ", wordPair{0, 1}))
+	})
+
+	t.Run("filters pairs", func(t *testing.T) {
+		xg := createXordleGame(&guesses, &solutions)
+		xg.guess("AEZ", "HHH")
+		if len(xg.remainingPairs) != 0 {
+			t.Errorf("Expected no pair, got %v", xg.remainingPairs)
+		}
+		xg = createXordleGame(&guesses, &solutions)
+		xg.guess("AEZ", "H.H")
+		if len(xg.remainingPairs) != 1 || xg.pairWords(xg.remainingPairs[0]) != "ABC+XYZ" {
+			t.Errorf("Expected ABC+XYZ, got %v", xg.remainingPairs)
+		}
+	})
+
+	t.Run("filters pairs with unknown tiles", func(t *testing.T) {
+		xg := createXordleGame(&guesses, &solutions)
+		xg.guess("AEZ", "??H")
+		compareWordSlices(t, xg.remainingWords(), &[]string{"ABC+XYZ", "DEF+XYZ"})
+	})
+
+	t.Run("ranks guesses by the pair distribution", func(t *testing.T) {
+		xg := createXordleGame(&guesses, &solutions)
+		distribution := xg.scoreDistribution("AEZ")
+		total := 0
+		for _, count := range distribution {
+			total += count
+		}
+		if total != len(xg.remainingPairs) {
+			t.Errorf("Expected %v pairs in the distribution, got %v", len(xg.remainingPairs), total)
+		}
+		if len(*xg.getBestGuesses()) != 2 {
+			t.Errorf("Expected 2 ranked guesses")
+		}
+	})
+}